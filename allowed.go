@@ -0,0 +1,63 @@
+package enum
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// ellipsis ends lists capped by Options.MaxListed.
+const ellipsis = "..."
+
+// AllowedNames returns the dotted names of the leaf members of enum joined with sep, for
+// error messages saying what would have been accepted. Names are listed in the order
+// selected by WithOrder, declaration order by default, and WithMaxListed caps the list
+// with a trailing "...". Members that cannot be ordered by value are listed in
+// declaration order. Returns "" if enum is not a struct.
+func AllowedNames(enum any, sep string, opts ...Option) string {
+	enumVal, ok := structValue(enum)
+	if !ok {
+		return ""
+	}
+	o := buildOptions(opts)
+	members, _ := sortedFields(leaves(enumVal, o.tagKey()), opts)
+	return joinAllowed(memberNames(members), sep, o.MaxListed)
+}
+
+// AllowedValues is AllowedNames for the values of the leaf members. Strings are listed
+// as they are unless they contain sep, in which case they are quoted as Go strings.
+func AllowedValues(enum any, sep string, opts ...Option) string {
+	enumVal, ok := structValue(enum)
+	if !ok {
+		return ""
+	}
+	o := buildOptions(opts)
+	members, _ := sortedFields(leaves(enumVal, o.tagKey()), opts)
+	var values []string
+	for _, m := range members {
+		value := fmt.Sprint(m.value.Interface())
+		if s, ok := m.value.Interface().(string); ok && sep != "" && strings.Contains(s, sep) {
+			value = strconv.Quote(s)
+		}
+		values = append(values, value)
+	}
+	return joinAllowed(values, sep, o.MaxListed)
+}
+
+// memberNames returns the dotted names of members.
+func memberNames(members []member) []string {
+	names := make([]string, len(members))
+	for i, m := range members {
+		names[i] = m.path
+	}
+	return names
+}
+
+// joinAllowed joins items with sep, keeping the first limit of them followed by an
+// ellipsis if there are more. A limit of zero keeps them all.
+func joinAllowed(items []string, sep string, limit int) string {
+	if limit > 0 && len(items) > limit {
+		items = append(items[:limit:limit], ellipsis)
+	}
+	return strings.Join(items, sep)
+}
@@ -0,0 +1,75 @@
+package enum
+
+import (
+	"strings"
+	"testing"
+)
+
+// AllowedStatus is the enum of the AllowedNames and AllowedValues tests.
+type AllowedStatus struct {
+	Code struct {
+		StatusOK       int `enum:"200"`
+		StatusNotFound int `enum:"404"`
+	}
+	Greeting string `enum:"Hello\\, world"`
+	Default  string
+}
+
+// TestAllowedNames tests ordering and the ellipsis cap.
+func TestAllowedNames(t *testing.T) {
+	status := New[AllowedStatus]()
+	tests := []struct {
+		sep  string
+		opts []Option
+		want string
+	}{
+		{", ", nil, "Code.StatusOK, Code.StatusNotFound, Greeting, Default"},
+		{"|", []Option{WithOrder(ByName)}, "Code.StatusNotFound|Code.StatusOK|Default|Greeting"},
+		{", ", []Option{WithMaxListed(2)}, "Code.StatusOK, Code.StatusNotFound, ..."},
+		{", ", []Option{WithMaxListed(4)}, "Code.StatusOK, Code.StatusNotFound, Greeting, Default"},
+	}
+	for _, tt := range tests {
+		if got := AllowedNames(status, tt.sep, tt.opts...); got != tt.want {
+			t.Errorf("AllowedNames(%q) = %q; want %q", tt.sep, got, tt.want)
+		}
+	}
+	if got := AllowedNames(42, ", "); got != "" {
+		t.Errorf("AllowedNames(42) = %q; want \"\"", got)
+	}
+}
+
+// TestAllowedValues tests the quoting of values containing the separator and the cap.
+func TestAllowedValues(t *testing.T) {
+	status := New[AllowedStatus]()
+	if got, want := AllowedValues(status, ", "), `200, 404, "Hello, world", Default`; got != want {
+		t.Errorf("AllowedValues(\", \") = %q; want %q", got, want)
+	}
+	if got, want := AllowedValues(status, " | "), "200 | 404 | Hello, world | Default"; got != want {
+		t.Errorf("AllowedValues(\" | \") = %q; want %q", got, want)
+	}
+	if got, want := AllowedValues(status.Code, ", ", WithMaxListed(1)), "200, ..."; got != want {
+		t.Errorf("AllowedValues(Code, WithMaxListed(1)) = %q; want %q", got, want)
+	}
+}
+
+// TestAllowedInErrors tests that Parse, ParseSlice, HandleOf, and ParseFlags list the
+// valid names like AllowedNames.
+func TestAllowedInErrors(t *testing.T) {
+	_, err := ParseFlags[uint8](Permission, "Delete", WithMaxListed(2))
+	if err == nil || !strings.HasSuffix(err.Error(), "valid flags are Read, Write, ...") {
+		t.Errorf("ParseFlags(WithMaxListed(2)) error = %v; want a capped list", err)
+	}
+
+	_, err = Parse[uint8](Permission, "Delete")
+	if want := `unknown member "Delete"; valid names are Read, Write, Exec`; err == nil || err.Error() != want {
+		t.Errorf("Parse(Delete) error = %v; want %q", err, want)
+	}
+	_, err = HandleOf[uint8](Permission, "Delete", WithMaxListed(1))
+	if want := `unknown member "Delete"; valid names are Read, ...`; err == nil || err.Error() != want {
+		t.Errorf("HandleOf(Delete, WithMaxListed(1)) error = %v; want %q", err, want)
+	}
+	_, err = ParseSlice[uint8](Permission, []string{"Read", "Delete", "Drop"})
+	if want := `invalid names: [1] unknown member "Delete"; [2] unknown member "Drop"; valid names are Read, Write, Exec`; err == nil || err.Error() != want {
+		t.Errorf("ParseSlice() error = %v; want %q", err, want)
+	}
+}
@@ -0,0 +1,121 @@
+package enum
+
+import (
+	"fmt"
+	"reflect"
+)
+
+// SetField assigns value to the leaf member named by name, which may be a dotted path
+// such as "Code.StatusOK", in the enum pointed to by enumPtr. The value is checked like
+// a tag during initialization: integers of any kind are accepted for integer members as
+// long as they fit, strings for string members, and other values must be assignable.
+// Returns an error if enumPtr is not a non-nil pointer to a struct, if the member does
+// not exist, or if the value does not fit the member.
+func SetField(enumPtr any, name string, value any) error {
+	ptrVal := reflect.ValueOf(enumPtr)
+	if ptrVal.Kind() != reflect.Ptr || ptrVal.IsNil() || ptrVal.Elem().Kind() != reflect.Struct {
+		return fmt.Errorf("type %T is not a pointer to a struct", enumPtr)
+	}
+
+	field, ok := FieldAddr(enumPtr, name)
+	if !ok {
+		return fmt.Errorf("unknown member %q", name)
+	}
+	if err := assign(field, value); err != nil {
+		return fmt.Errorf("field %s: %v", name, err)
+	}
+	return nil
+}
+
+// FieldAddr returns the addressable, settable value of the leaf member named by name,
+// which may be a dotted path, in the enum pointed to by enumPtr, for updating a member
+// in place after construction; its Addr method yields a typed pointer such as *int.
+// Unlike SetField, stores through the value are not checked. The second
+// result is false if enumPtr is not a non-nil pointer to a struct or there is no such
+// member.
+func FieldAddr(enumPtr any, name string) (reflect.Value, bool) {
+	ptrVal := reflect.ValueOf(enumPtr)
+	if ptrVal.Kind() != reflect.Ptr || ptrVal.IsNil() || ptrVal.Elem().Kind() != reflect.Struct {
+		return reflect.Value{}, false
+	}
+	m, ok := findLeaf(ptrVal.Elem(), name, "enum")
+	if !ok {
+		return reflect.Value{}, false
+	}
+	return m.value, true
+}
+
+// assign stores value into the settable field, converting between integer kinds with
+// the same overflow checks applied to tags.
+func assign(field reflect.Value, value any) error {
+	val := reflect.ValueOf(value)
+	if !val.IsValid() {
+		return fmt.Errorf("cannot assign nil to %s", field.Type())
+	}
+
+	switch field.Kind() {
+	case reflect.String:
+		if val.Kind() != reflect.String {
+			return fmt.Errorf("cannot assign %T to %s", value, field.Type())
+		}
+		field.SetString(val.String())
+		return nil
+
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		n, err := signedValue(val)
+		if err != nil {
+			return fmt.Errorf("cannot assign %T to %s: %v", value, field.Type(), err)
+		}
+		if err := checkIntOverflow(n, field.Kind()); err != nil {
+			return err
+		}
+		field.SetInt(n)
+		return nil
+
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		n, err := unsignedValue(val)
+		if err != nil {
+			return fmt.Errorf("cannot assign %T to %s: %v", value, field.Type(), err)
+		}
+		if err := checkUintOverflow(n, field.Kind()); err != nil {
+			return err
+		}
+		field.SetUint(n)
+		return nil
+	}
+
+	// Fall back to plain assignability for other field types.
+	if !val.Type().AssignableTo(field.Type()) {
+		return fmt.Errorf("cannot assign %T to %s", value, field.Type())
+	}
+	field.Set(val)
+	return nil
+}
+
+// signedValue converts an integer value of any kind to int64.
+func signedValue(val reflect.Value) (int64, error) {
+	switch val.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return val.Int(), nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		if val.Uint() > 1<<63-1 {
+			return 0, fmt.Errorf("value %d overflows int64", val.Uint())
+		}
+		return int64(val.Uint()), nil
+	}
+	return 0, fmt.Errorf("not an integer")
+}
+
+// unsignedValue converts a non-negative integer value of any kind to uint64.
+func unsignedValue(val reflect.Value) (uint64, error) {
+	switch val.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		if val.Int() < 0 {
+			return 0, fmt.Errorf("value %d is negative", val.Int())
+		}
+		return uint64(val.Int()), nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return val.Uint(), nil
+	}
+	return 0, fmt.Errorf("not an integer")
+}
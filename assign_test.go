@@ -0,0 +1,83 @@
+package enum
+
+import (
+	"strings"
+	"testing"
+)
+
+// TestSetField tests patching members by dotted name with validation.
+func TestSetField(t *testing.T) {
+	HttpStatus := New[struct {
+		Code struct {
+			StatusOK int16 `enum:"200"`
+			Retries  uint8 `enum:"3"`
+		}
+		Default string
+	}]()
+
+	if err := SetField(&HttpStatus, "Code.StatusOK", 299); err != nil || HttpStatus.Code.StatusOK != 299 {
+		t.Errorf("SetField(Code.StatusOK, 299) = %v; got value %d", err, HttpStatus.Code.StatusOK)
+	}
+	if err := SetField(&HttpStatus, "Code.Retries", uint64(5)); err != nil || HttpStatus.Code.Retries != 5 {
+		t.Errorf("SetField(Code.Retries, 5) = %v; got value %d", err, HttpStatus.Code.Retries)
+	}
+	if err := SetField(&HttpStatus, "Default", "Fallback"); err != nil || HttpStatus.Default != "Fallback" {
+		t.Errorf("SetField(Default) = %v; got value %q", err, HttpStatus.Default)
+	}
+
+	failures := []struct {
+		name  string
+		value any
+		want  string
+	}{
+		{"Code.StatusOK", 40000, "overflows int16"},
+		{"Code.Retries", -1, "negative"},
+		{"Code.StatusOK", "200", "cannot assign string"},
+		{"Default", 1, "cannot assign int"},
+		{"Code.Missing", 1, "unknown member"},
+	}
+	for _, tt := range failures {
+		if err := SetField(&HttpStatus, tt.name, tt.value); err == nil || !strings.Contains(err.Error(), tt.want) {
+			t.Errorf("SetField(%s, %v) = %v; want error containing %q", tt.name, tt.value, err, tt.want)
+		}
+	}
+	if HttpStatus.Code.StatusOK != 299 || HttpStatus.Code.Retries != 5 {
+		t.Errorf("failed SetField calls modified the enum: %+v", HttpStatus.Code)
+	}
+	if err := SetField(HttpStatus, "Default", "x"); err == nil {
+		t.Errorf("SetField on a non-pointer returned nil error; want error")
+	}
+}
+
+// TestFieldAddr tests that member addresses update the enum in place.
+func TestFieldAddr(t *testing.T) {
+	status := New[struct {
+		Code struct {
+			StatusOK int `enum:"200"`
+		}
+		Default string
+	}]()
+
+	field, ok := FieldAddr(&status, "Code.StatusOK")
+	if !ok || !field.CanSet() {
+		t.Fatalf("FieldAddr(Code.StatusOK) = %v, %v; want a settable value", field, ok)
+	}
+	*field.Addr().Interface().(*int) = 299
+	if status.Code.StatusOK != 299 {
+		t.Errorf("Code.StatusOK = %d after update; want 299", status.Code.StatusOK)
+	}
+
+	for _, tt := range []struct {
+		enumPtr any
+		name    string
+	}{
+		{status, "Default"},
+		{(*struct{ A int })(nil), "A"},
+		{&status, "Code"},
+		{&status, "Missing"},
+	} {
+		if _, ok := FieldAddr(tt.enumPtr, tt.name); ok {
+			t.Errorf("FieldAddr(%T, %q) ok = true; want false", tt.enumPtr, tt.name)
+		}
+	}
+}
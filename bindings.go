@@ -0,0 +1,87 @@
+package enum
+
+import (
+	"fmt"
+	"go/format"
+	"io"
+	"reflect"
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// GenerateBindings initializes enum and writes Go source for package pkg that declares it
+// without reflection, for targets such as TinyGo where package enum cannot run. The file
+// declares a variable varName holding every member value as a composite literal, and the
+// functions varName+"Keys", varName+"Values", varName+"Contains", and varName+"Parse",
+// which behave like Keys, Values, Contains, and Parse with WithNested over static tables
+// of the leaf members in declaration order. Values and Parse return the members as any,
+// and Contains compares both type and value. The output is deterministic and gofmt-clean
+// and does not import package enum: entries sentinels are omitted, and only string and
+// integer members are supported.
+func GenerateBindings(w io.Writer, enum any, pkg, varName string) error {
+	val, ok := structValue(enum)
+	if !ok {
+		return fmt.Errorf("type %T is not a struct", enum)
+	}
+	members := leaves(val, "enum")
+	for _, m := range members {
+		switch m.value.Kind() {
+		case reflect.String, reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+			reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		default:
+			return fmt.Errorf("member %s: type %s is not supported without reflection", m.path, m.value.Type())
+		}
+	}
+
+	g := newGenerator(pkg)
+	g.omitEntries = true
+	typ := g.structExpr(val.Type())
+	lit, err := g.literal(val, "")
+	if err != nil {
+		return err
+	}
+	if len(g.imports) > 0 {
+		return fmt.Errorf("type %s refers to other packages", val.Type())
+	}
+
+	tables := lowerFirst(varName)
+	var buf strings.Builder
+	buf.WriteString("// Code generated by enum.GenerateBindings; DO NOT EDIT.\n\n")
+	buf.WriteString("package " + g.pkg + "\n\n")
+	fmt.Fprintf(&buf, "var %s = %s%s\n\n", varName, typ, lit)
+
+	fmt.Fprintf(&buf, "var %sMemberNames = []string{\n", tables)
+	for _, m := range members {
+		buf.WriteString(strconv.Quote(m.path) + ",\n")
+	}
+	buf.WriteString("}\n\n")
+	fmt.Fprintf(&buf, "var %sMemberValues = []any{\n", tables)
+	for _, m := range members {
+		buf.WriteString(varName + "." + m.path + ",\n")
+	}
+	buf.WriteString("}\n\n")
+
+	fmt.Fprintf(&buf, "// %sKeys returns the names of the members of %s.\n", varName, varName)
+	fmt.Fprintf(&buf, "func %sKeys() []string {\nreturn append([]string(nil), %sMemberNames...)\n}\n\n", varName, tables)
+	fmt.Fprintf(&buf, "// %sValues returns the values of the members of %s.\n", varName, varName)
+	fmt.Fprintf(&buf, "func %sValues() []any {\nreturn append([]any(nil), %sMemberValues...)\n}\n\n", varName, tables)
+	fmt.Fprintf(&buf, "// %sContains reports whether value is the value of a member of %s.\n", varName, varName)
+	fmt.Fprintf(&buf, "func %sContains(value any) bool {\nfor _, v := range %sMemberValues {\nif v == value {\nreturn true\n}\n}\nreturn false\n}\n\n", varName, tables)
+	fmt.Fprintf(&buf, "// %sParse returns the value of the member of %s named name.\n", varName, varName)
+	fmt.Fprintf(&buf, "func %sParse(name string) (any, bool) {\nfor i, n := range %sMemberNames {\nif n == name {\nreturn %sMemberValues[i], true\n}\n}\nreturn nil, false\n}\n", varName, tables, tables)
+
+	src, err := format.Source([]byte(buf.String()))
+	if err != nil {
+		return fmt.Errorf("formatting generated source: %v", err)
+	}
+	_, err = w.Write(src)
+	return err
+}
+
+// lowerFirst returns s with its first letter lowercased.
+func lowerFirst(s string) string {
+	r, size := utf8.DecodeRuneInString(s)
+	return string(unicode.ToLower(r)) + s[size:]
+}
@@ -0,0 +1,129 @@
+package enum
+
+import (
+	"fmt"
+	"go/ast"
+	"go/importer"
+	"go/parser"
+	"go/token"
+	"go/types"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// bindingsEnum is the enum used to compare generated bindings with the reflective path.
+type bindingsEnum struct {
+	Code struct {
+		StatusOK       int `enum:"200"`
+		StatusNotFound int `enum:"404"`
+	}
+	Level struct {
+		Low  uint8 `enum:"1"`
+		High uint8 `enum:"9"`
+	}
+	Name  string
+	Count int `enum:"count"`
+}
+
+// TestGenerateBindings tests that the generated bindings type-check, are deterministic,
+// and do not import package enum.
+func TestGenerateBindings(t *testing.T) {
+	e := New[bindingsEnum]()
+	var buf, again strings.Builder
+	if err := GenerateBindings(&buf, e, "status", "Status"); err != nil {
+		t.Fatalf("GenerateBindings() error = %v", err)
+	}
+	if err := GenerateBindings(&again, e, "status", "Status"); err != nil || again.String() != buf.String() {
+		t.Errorf("GenerateBindings() is not deterministic")
+	}
+
+	src := buf.String()
+	fset := token.NewFileSet()
+	file, err := parser.ParseFile(fset, "bindings.go", src, 0)
+	if err != nil {
+		t.Fatalf("parsing generated code: %v\n%s", err, src)
+	}
+	if len(file.Imports) != 0 {
+		t.Errorf("generated code imports %d packages, want none", len(file.Imports))
+	}
+	pkg, err := (&types.Config{Importer: importer.ForCompiler(fset, "source", nil)}).Check("status", fset, []*ast.File{file}, nil)
+	if err != nil {
+		t.Fatalf("type-checking generated code: %v\n%s", err, src)
+	}
+	for _, name := range []string{"Status", "StatusKeys", "StatusValues", "StatusContains", "StatusParse"} {
+		if pkg.Scope().Lookup(name) == nil {
+			t.Errorf("generated code does not declare %s", name)
+		}
+	}
+}
+
+// TestGenerateBindingsBehavior tests that a program using the generated bindings reports
+// the same members as the reflective functions.
+func TestGenerateBindingsBehavior(t *testing.T) {
+	if testing.Short() {
+		t.Skip("runs the go command")
+	}
+	goCmd, err := exec.LookPath("go")
+	if err != nil {
+		t.Skip("go command not found")
+	}
+
+	e := New[bindingsEnum]()
+	var bindings strings.Builder
+	if err := GenerateBindings(&bindings, e, "main", "Status"); err != nil {
+		t.Fatalf("GenerateBindings() error = %v", err)
+	}
+	dir := t.TempDir()
+	files := map[string]string{
+		"go.mod":      "module bindings\n\ngo 1.18\n",
+		"bindings.go": bindings.String(),
+		"main.go": "package main\n\nimport \"fmt\"\n\nfunc main() {\n" +
+			"\tfor i, key := range StatusKeys() {\n" +
+			"\t\tv, ok := StatusParse(key)\n" +
+			"\t\tfmt.Printf(\"%s %T %v %v %v\\n\", key, v, v, ok, StatusContains(StatusValues()[i]))\n" +
+			"\t}\n" +
+			"\t_, ok := StatusParse(\"Missing\")\n" +
+			"\tfmt.Println(ok, StatusContains(uint8(200)), Status.Count)\n}\n",
+	}
+	for name, content := range files {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	cmd := exec.Command(goCmd, "run", ".")
+	cmd.Dir = dir
+	cmd.Env = append(os.Environ(), "GOFLAGS=-mod=mod", "GOPROXY=off")
+	out, err := cmd.CombinedOutput()
+	if err != nil {
+		t.Fatalf("go run: %v\n%s", err, out)
+	}
+
+	var want strings.Builder
+	for _, key := range Keys(e, WithNested()) {
+		v, err := Parse[any](e, key)
+		// Every leaf is a member; Contains is checked with its type below.
+		fmt.Fprintf(&want, "%s %T %v %v %v\n", key, v, v, err == nil, err == nil)
+	}
+	_, err = Parse[any](e, "Missing")
+	fmt.Fprintln(&want, err == nil, Contains(e, uint8(200), WithNested()), e.Count)
+	if string(out) != want.String() {
+		t.Errorf("generated bindings output:\n%s\nwant:\n%s", out, want.String())
+	}
+}
+
+// TestGenerateBindingsErrors tests that members without a literal form are rejected.
+func TestGenerateBindingsErrors(t *testing.T) {
+	e := New[struct {
+		OK CodeLabel `enum:"200:OK"`
+	}]()
+	err := GenerateBindings(&strings.Builder{}, e, "p", "E")
+	if err == nil || !strings.Contains(err.Error(), "member OK: type enum.CodeLabel is not supported without reflection") {
+		t.Errorf("GenerateBindings() error = %v", err)
+	}
+	if err := GenerateBindings(&strings.Builder{}, 1, "p", "E"); err == nil {
+		t.Errorf("GenerateBindings(1) error = nil, want an error")
+	}
+}
@@ -0,0 +1,84 @@
+package enum
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// Checked is a value known to be the value of a member of an enum. It can only be
+// constructed by ParseChecked, CheckValue, and UnmarshalChecked, so functions taking a
+// Checked[V] instead of a V need not validate it again. The zero Checked holds no member
+// and reports false from Ok.
+type Checked[V any] struct {
+	name  string
+	value V
+	ok    bool
+}
+
+// ParseChecked returns the leaf member of enum named by name, like Parse.
+func ParseChecked[V any](enum any, name string, opts ...Option) (Checked[V], error) {
+	value, err := Parse[V](enum, name, opts...)
+	if err != nil {
+		return Checked[V]{}, err
+	}
+	enumVal, _ := structValue(enum)
+	o := buildOptions(opts)
+	m, _ := resolveLeaf(enumVal, name, &o)
+	return Checked[V]{name: m.path, value: value, ok: true}, nil
+}
+
+// CheckValue returns v as the first leaf member of enum of type V holding it.
+// Returns an error if enum is not a struct or no such member exists.
+func CheckValue[V comparable](enum any, v V) (Checked[V], error) {
+	enumVal, ok := structValue(enum)
+	if !ok {
+		return Checked[V]{}, fmt.Errorf("type %T is not a struct", enum)
+	}
+	for _, m := range leaves(enumVal, "enum") {
+		if value, ok := m.value.Interface().(V); ok && value == v {
+			return Checked[V]{name: m.path, value: value, ok: true}, nil
+		}
+	}
+	return Checked[V]{}, fmt.Errorf("value %v is not a member of %T", v, enum)
+}
+
+// UnmarshalChecked decodes a value encoded by Checked.MarshalJSON and checks it against
+// enum like CheckValue. Decoding needs the enum, so Checked has no UnmarshalJSON method.
+func UnmarshalChecked[V comparable](enum any, data []byte) (Checked[V], error) {
+	var v V
+	if err := json.Unmarshal(data, &v); err != nil {
+		return Checked[V]{}, err
+	}
+	return CheckValue(enum, v)
+}
+
+// Ok reports whether c holds a member, which is false for the zero Checked.
+func (c Checked[V]) Ok() bool {
+	return c.ok
+}
+
+// Name returns the dotted name of the member, or "" for the zero Checked.
+func (c Checked[V]) Name() string {
+	return c.name
+}
+
+// Value returns the value of the member, or the zero V for the zero Checked.
+func (c Checked[V]) Value() V {
+	return c.value
+}
+
+// String returns the member's name, or "<invalid>" for the zero Checked.
+func (c Checked[V]) String() string {
+	if !c.ok {
+		return "<invalid>"
+	}
+	return c.name
+}
+
+// MarshalJSON encodes the member's value, or null for the zero Checked.
+func (c Checked[V]) MarshalJSON() ([]byte, error) {
+	if !c.ok {
+		return []byte("null"), nil
+	}
+	return json.Marshal(c.value)
+}
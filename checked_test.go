@@ -0,0 +1,64 @@
+package enum
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+// CheckedStatus is the enum validated by the Checked tests.
+type CheckedStatus struct {
+	Code struct {
+		StatusOK       int `enum:"200"`
+		StatusNotFound int `enum:"404"`
+	}
+	Reason string `enum:"not found"`
+}
+
+// TestChecked tests construction and the zero-value guard.
+func TestChecked(t *testing.T) {
+	Status := New[CheckedStatus]()
+
+	c, err := ParseChecked[int](Status, "code.statusok", WithIgnoreCase())
+	if err != nil || !c.Ok() || c.Name() != "Code.StatusOK" || c.Value() != 200 || c.String() != "Code.StatusOK" {
+		t.Errorf("ParseChecked() = %v (%v), %v; want Code.StatusOK = 200", c, c.Value(), err)
+	}
+	if _, err := ParseChecked[int](Status, "Reason"); err == nil {
+		t.Error("ParseChecked[int](Reason) returned nil error")
+	}
+
+	c, err = CheckValue(Status, 404)
+	if err != nil || !c.Ok() || c.Name() != "Code.StatusNotFound" {
+		t.Errorf("CheckValue(404) = %v, %v; want Code.StatusNotFound", c, err)
+	}
+	if c, err := CheckValue(Status, 500); err == nil || c.Ok() {
+		t.Errorf("CheckValue(500) = %v, %v; want invalid and an error", c, err)
+	}
+
+	var zero Checked[int]
+	if zero.Ok() || zero.Name() != "" || zero.Value() != 0 || zero.String() != "<invalid>" {
+		t.Errorf("zero Checked = %v; want invalid", zero)
+	}
+}
+
+// TestCheckedJSON tests that Checked values round-trip through JSON.
+func TestCheckedJSON(t *testing.T) {
+	Status := New[CheckedStatus]()
+	c, _ := CheckValue(Status, "not found")
+	data, err := json.Marshal(struct{ Reason Checked[string] }{c})
+	if err != nil || string(data) != `{"Reason":"not found"}` {
+		t.Fatalf("json.Marshal() = %s, %v", data, err)
+	}
+	got, err := UnmarshalChecked[string](Status, []byte(`"not found"`))
+	if err != nil || got != c {
+		t.Errorf("UnmarshalChecked() = %v, %v; want %v", got, err, c)
+	}
+	if _, err := UnmarshalChecked[string](Status, []byte(`"gone"`)); err == nil {
+		t.Error("UnmarshalChecked() of a non-member returned nil error")
+	}
+	if _, err := UnmarshalChecked[int](Status, []byte(`"200"`)); err == nil {
+		t.Error("UnmarshalChecked() of a mistyped value returned nil error")
+	}
+	if data, _ := json.Marshal(Checked[int]{}); string(data) != "null" {
+		t.Errorf("json.Marshal(zero) = %s; want null", data)
+	}
+}
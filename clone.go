@@ -0,0 +1,74 @@
+package enum
+
+import "reflect"
+
+// Clone returns a deep copy of src, so that mutating the copy, for example to apply
+// per-tenant overrides, never affects the original. Slices, maps, pointers, and
+// interfaces reachable through exported fields are copied recursively. Unexported
+// fields are copied shallowly, as they cannot be set through reflection.
+// src must not contain pointer cycles.
+func Clone[T any](src T) T {
+	dst := src
+	deepCopy(reflect.ValueOf(&dst).Elem())
+	return dst
+}
+
+// deepCopy replaces the referenced contents of the settable value val with copies.
+func deepCopy(val reflect.Value) {
+	switch val.Kind() {
+	case reflect.Struct:
+		for i := 0; i < val.NumField(); i++ {
+			if field := val.Field(i); field.CanSet() {
+				deepCopy(field)
+			}
+		}
+
+	case reflect.Array:
+		for i := 0; i < val.Len(); i++ {
+			deepCopy(val.Index(i))
+		}
+
+	case reflect.Slice:
+		if val.IsNil() {
+			return
+		}
+		copied := reflect.MakeSlice(val.Type(), val.Len(), val.Len())
+		reflect.Copy(copied, val)
+		for i := 0; i < copied.Len(); i++ {
+			deepCopy(copied.Index(i))
+		}
+		val.Set(copied)
+
+	case reflect.Map:
+		if val.IsNil() {
+			return
+		}
+		copied := reflect.MakeMapWithSize(val.Type(), val.Len())
+		iter := val.MapRange()
+		for iter.Next() {
+			elem := reflect.New(val.Type().Elem()).Elem()
+			elem.Set(iter.Value())
+			deepCopy(elem)
+			copied.SetMapIndex(iter.Key(), elem)
+		}
+		val.Set(copied)
+
+	case reflect.Ptr:
+		if val.IsNil() {
+			return
+		}
+		copied := reflect.New(val.Type().Elem())
+		copied.Elem().Set(val.Elem())
+		deepCopy(copied.Elem())
+		val.Set(copied)
+
+	case reflect.Interface:
+		if val.IsNil() {
+			return
+		}
+		elem := reflect.New(val.Elem().Type()).Elem()
+		elem.Set(val.Elem())
+		deepCopy(elem)
+		val.Set(elem)
+	}
+}
@@ -0,0 +1,52 @@
+package enum
+
+import (
+	"reflect"
+	"testing"
+)
+
+// TestCloneFlat tests that cloning a flat enum yields an identical value.
+func TestCloneFlat(t *testing.T) {
+	HttpStatus := New[struct {
+		Code struct {
+			StatusOK int `enum:"200"`
+		}
+		Default string
+	}]()
+	if got := Clone(HttpStatus); got != HttpStatus {
+		t.Errorf("Clone() = %+v; want %+v", got, HttpStatus)
+	}
+}
+
+// TestCloneDeep tests that reference-typed members of a clone do not alias the original.
+func TestCloneDeep(t *testing.T) {
+	type Tenant struct {
+		Codes   []int
+		Labels  map[string][]string
+		Limit   *int
+		Extra   any
+		private []int
+	}
+	limit := 10
+	src := Tenant{
+		Codes:   []int{200, 404},
+		Labels:  map[string][]string{"ok": {"OK", "Fine"}},
+		Limit:   &limit,
+		Extra:   []string{"a"},
+		private: []int{1},
+	}
+
+	dst := Clone(src)
+	if !reflect.DeepEqual(dst, src) {
+		t.Fatalf("Clone() = %+v; want %+v", dst, src)
+	}
+	dst.Codes[0] = 299
+	dst.Labels["ok"][0] = "Changed"
+	dst.Labels["new"] = nil
+	*dst.Limit = 20
+	dst.Extra.([]string)[0] = "b"
+
+	if src.Codes[0] != 200 || src.Labels["ok"][0] != "OK" || len(src.Labels) != 1 || limit != 10 || src.Extra.([]string)[0] != "a" {
+		t.Errorf("mutating the clone changed the original: %+v", src)
+	}
+}
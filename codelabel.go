@@ -0,0 +1,50 @@
+package enum
+
+import (
+	"fmt"
+	"reflect"
+)
+
+// CodeLabel is a member type carrying an integer code together with a human-readable
+// label, filled from a single "code:label" tag such as `enum:"404:Not Found"`.
+// Any struct with exactly an integer field named Code and a string field named Label
+// is treated the same way, so callers may declare their own equivalent type.
+// Untagged code-label members take the default integer and string values.
+type CodeLabel struct {
+	Code  int
+	Label string
+}
+
+// isCodeLabel reports whether t is a struct with exactly an integer Code field and a
+// string Label field.
+func isCodeLabel(t reflect.Type) bool {
+	if t.Kind() != reflect.Struct || t.NumField() != 2 {
+		return false
+	}
+	code, ok := t.FieldByName("Code")
+	if !ok || code.PkgPath != "" || !isIntegerKind(code.Type.Kind()) {
+		return false
+	}
+	label, ok := t.FieldByName("Label")
+	return ok && label.PkgPath == "" && label.Type.Kind() == reflect.String
+}
+
+// isIntegerKind reports whether kind is a signed or unsigned integer kind.
+func isIntegerKind(kind reflect.Kind) bool {
+	switch kind {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return true
+	}
+	return false
+}
+
+// setCodeLabel stores code and label into a code-label struct value, checking that
+// the code fits the Code field.
+func setCodeLabel(val reflect.Value, code int64, label string) error {
+	if err := assign(val.FieldByName("Code"), code); err != nil {
+		return fmt.Errorf("code: %v", err)
+	}
+	val.FieldByName("Label").SetString(label)
+	return nil
+}
@@ -0,0 +1,74 @@
+package enum
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+)
+
+// TestCodeLabel tests code-label members filled from a single tag.
+func TestCodeLabel(t *testing.T) {
+	type Reason struct {
+		Code  uint16
+		Label string
+	}
+	got := New[struct {
+		StatusOK       CodeLabel `enum:"200:OK"`
+		StatusNotFound CodeLabel `enum:"404:Not Found: try again"`
+		Custom         Reason    `enum:"500:Internal Server Error"`
+		Untagged       CodeLabel
+	}]()
+
+	if got.StatusOK != (CodeLabel{200, "OK"}) {
+		t.Errorf("got StatusOK %+v, want {200 OK}", got.StatusOK)
+	}
+	if got.StatusNotFound != (CodeLabel{404, "Not Found: try again"}) {
+		t.Errorf("got StatusNotFound %+v, want {404 Not Found: try again}", got.StatusNotFound)
+	}
+	if got.Custom != (Reason{500, "Internal Server Error"}) {
+		t.Errorf("got Custom %+v, want {500 Internal Server Error}", got.Custom)
+	}
+	if got.Untagged != (CodeLabel{3, "Untagged"}) {
+		t.Errorf("got Untagged %+v, want {3 Untagged}", got.Untagged)
+	}
+	if entries := Entries(got); len(entries) != 4 || entries[0].Value != (CodeLabel{200, "OK"}) {
+		t.Errorf("Entries() = %v; want the four code-label members", entries)
+	}
+}
+
+// TestCodeLabelMalformed tests that malformed code-label tags panic.
+func TestCodeLabelMalformed(t *testing.T) {
+	tests := []struct {
+		build func()
+		want  string
+	}{
+		{func() {
+			New[struct {
+				A CodeLabel `enum:"404"`
+			}]()
+		}, "not of the form code:label"},
+		{func() {
+			New[struct {
+				A CodeLabel `enum:"abc:Label"`
+			}]()
+		}, "invalid syntax"},
+		{func() {
+			New[struct {
+				A struct {
+					Code  int8
+					Label string
+				} `enum:"300:Too Big"`
+			}]()
+		}, "overflows int8"},
+	}
+	for _, tt := range tests {
+		func() {
+			defer func() {
+				if r := recover(); r == nil || !strings.Contains(fmt.Sprint(r), tt.want) {
+					t.Errorf("panic = %v; want panic containing %q", r, tt.want)
+				}
+			}()
+			tt.build()
+		}()
+	}
+}
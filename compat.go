@@ -0,0 +1,172 @@
+package enum
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// Report describes the differences between a local enum and a remote definition, as
+// returned by CompatibleWith. Member lists follow the declaration order of the side that
+// has the members.
+type Report struct {
+	// Added lists the members only the remote side has. Such differences are backward
+	// compatible: the local side never produces those values.
+	Added []string `json:"added,omitempty"`
+
+	// Removed lists the members only the local side has. Such differences are forward
+	// incompatible: the remote side cannot interpret those values.
+	Removed []string `json:"removed,omitempty"`
+
+	// Changed lists the members both sides have with different values. Such differences
+	// are breaking.
+	Changed []Change `json:"changed,omitempty"`
+}
+
+// Change describes a member whose value differs between the two sides.
+type Change struct {
+	Name   string `json:"name"`
+	Mine   any    `json:"mine"`
+	Theirs any    `json:"theirs"`
+}
+
+// Compatible reports whether the two sides have no differences other than added members.
+func (r Report) Compatible() bool {
+	return len(r.Removed) == 0 && len(r.Changed) == 0
+}
+
+// Identical reports whether the two sides have no differences at all.
+func (r Report) Identical() bool {
+	return r.Compatible() && len(r.Added) == 0
+}
+
+// String describes the differences, one class per line, or "identical" if there are none.
+func (r Report) String() string {
+	if r.Identical() {
+		return "identical"
+	}
+	var lines []string
+	if len(r.Added) > 0 {
+		lines = append(lines, "backward-compatible: added "+strings.Join(r.Added, ", "))
+	}
+	if len(r.Removed) > 0 {
+		lines = append(lines, "forward-incompatible: removed "+strings.Join(r.Removed, ", "))
+	}
+	if len(r.Changed) > 0 {
+		changes := make([]string, len(r.Changed))
+		for i, c := range r.Changed {
+			changes[i] = fmt.Sprintf("%s %s -> %s", c.Name, formatValue(c.Mine), formatValue(c.Theirs))
+		}
+		lines = append(lines, "breaking: changed "+strings.Join(changes, ", "))
+	}
+	return strings.Join(lines, "\n")
+}
+
+// CompatibleWith compares the leaf members of mine with theirs, the Entries of the same
+// enum in another process, typically received as JSON, and classifies the differences.
+// Values are compared by their JSON encoding, so that a local int 200 equals a remote
+// float64 200 decoded by encoding/json, and code-label members equal decoded objects.
+// Returns an error if mine is not a struct or a value cannot be encoded as JSON.
+func CompatibleWith(mine any, theirs []Entry) (Report, error) {
+	var report Report
+	enumVal, ok := structValue(mine)
+	if !ok {
+		return report, fmt.Errorf("type %T is not a struct", mine)
+	}
+
+	remote := make(map[string]Entry, len(theirs))
+	for _, e := range theirs {
+		remote[e.Name] = e
+	}
+	local := make(map[string]bool)
+	for _, e := range entriesOf(enumVal, "enum") {
+		local[e.Name] = true
+		other, ok := remote[e.Name]
+		if !ok {
+			report.Removed = append(report.Removed, e.Name)
+			continue
+		}
+		same, err := sameJSON(e.Value, other.Value)
+		if err != nil {
+			return report, fmt.Errorf("member %s: %v", e.Name, err)
+		}
+		if !same {
+			report.Changed = append(report.Changed, Change{Name: e.Name, Mine: e.Value, Theirs: other.Value})
+		}
+	}
+	for _, e := range theirs {
+		if !local[e.Name] {
+			report.Added = append(report.Added, e.Name)
+		}
+	}
+	return report, nil
+}
+
+// Compatible compares the leaf members of the enum types A and B, such as the type of an
+// enum before and after a refactor, by dotted name and reflect.Kind, without initializing
+// either. Returns nil if both have the same members of the same kinds, or an error
+// listing the members added in B, the members removed from A, and the members whose
+// kind changed. Values are not compared; use CompatibleWith for that.
+func Compatible[A any, B any]() error {
+	before := reflect.TypeOf((*A)(nil)).Elem()
+	after := reflect.TypeOf((*B)(nil)).Elem()
+	if before.Kind() != reflect.Struct {
+		return fmt.Errorf("type %s is not a struct", before)
+	}
+	if after.Kind() != reflect.Struct {
+		return fmt.Errorf("type %s is not a struct", after)
+	}
+
+	kinds := make(map[string]reflect.Kind)
+	for _, m := range leaves(reflect.New(after).Elem(), "enum") {
+		kinds[m.path] = m.field.Type.Kind()
+	}
+	var removed, changed []string
+	seen := make(map[string]bool)
+	for _, m := range leaves(reflect.New(before).Elem(), "enum") {
+		seen[m.path] = true
+		kind, ok := kinds[m.path]
+		switch {
+		case !ok:
+			removed = append(removed, m.path)
+		case kind != m.field.Type.Kind():
+			changed = append(changed, fmt.Sprintf("%s (%s -> %s)", m.path, m.field.Type.Kind(), kind))
+		}
+	}
+	var added []string
+	for _, m := range leaves(reflect.New(after).Elem(), "enum") {
+		if !seen[m.path] {
+			added = append(added, m.path)
+		}
+	}
+
+	var problems []string
+	if len(added) > 0 {
+		problems = append(problems, "added "+strings.Join(added, ", "))
+	}
+	if len(removed) > 0 {
+		problems = append(problems, "removed "+strings.Join(removed, ", "))
+	}
+	if len(changed) > 0 {
+		problems = append(problems, "changed kind of "+strings.Join(changed, ", "))
+	}
+	if len(problems) > 0 {
+		return fmt.Errorf("incompatible enum types: %s", strings.Join(problems, "; "))
+	}
+	return nil
+}
+
+// sameJSON reports whether a and b have the same JSON encoding.
+func sameJSON(a, b any) (bool, error) {
+	aJSON, err := json.Marshal(a)
+	if err != nil {
+		return false, err
+	}
+	bJSON, err := json.Marshal(b)
+	if err != nil {
+		return false, err
+	}
+	return bytes.Equal(aJSON, bJSON), nil
+}
@@ -0,0 +1,109 @@
+package enum
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+)
+
+// CompatStatus is the local enum compared by the compatibility tests.
+type CompatStatus struct {
+	Code struct {
+		StatusOK       int `enum:"200"`
+		StatusNotFound int `enum:"404"`
+	}
+	Label CodeLabel `enum:"500:Internal Error"`
+}
+
+// remoteEntries returns the entries of enum as another process would receive them.
+func remoteEntries(t *testing.T, enum any) []Entry {
+	t.Helper()
+	data, err := json.Marshal(Entries(enum))
+	if err != nil {
+		t.Fatalf("json.Marshal() error = %v", err)
+	}
+	var entries []Entry
+	if err := json.Unmarshal(data, &entries); err != nil {
+		t.Fatalf("json.Unmarshal() error = %v", err)
+	}
+	return entries
+}
+
+// TestCompatibleWith tests each classification and the all-clear case.
+func TestCompatibleWith(t *testing.T) {
+	mine := New[CompatStatus]()
+
+	report, err := CompatibleWith(mine, remoteEntries(t, mine))
+	if err != nil || !report.Identical() || report.String() != "identical" {
+		t.Errorf("CompatibleWith(same) = %v, %v; want identical", report, err)
+	}
+
+	theirs := remoteEntries(t, New[struct {
+		Code struct {
+			StatusOK     int `enum:"201"`
+			StatusTeapot int `enum:"418"`
+		}
+		Label CodeLabel `enum:"500:Internal Error"`
+		Extra string
+	}]())
+	report, err = CompatibleWith(mine, theirs)
+	if err != nil {
+		t.Fatalf("CompatibleWith() error = %v", err)
+	}
+	want := Report{
+		Added:   []string{"Code.StatusTeapot", "Extra"},
+		Removed: []string{"Code.StatusNotFound"},
+		Changed: []Change{{Name: "Code.StatusOK", Mine: 200, Theirs: float64(201)}},
+	}
+	if !reflect.DeepEqual(report, want) {
+		t.Errorf("CompatibleWith() = %#v; want %#v", report, want)
+	}
+	if report.Compatible() || report.Identical() {
+		t.Error("report with removed and changed members is compatible")
+	}
+	wantString := "backward-compatible: added Code.StatusTeapot, Extra\n" +
+		"forward-incompatible: removed Code.StatusNotFound\n" +
+		"breaking: changed Code.StatusOK 200 -> 201"
+	if got := report.String(); got != wantString {
+		t.Errorf("String() =\n%s\nwant\n%s", got, wantString)
+	}
+
+	// Added members alone are backward compatible.
+	report, _ = CompatibleWith(mine, append(remoteEntries(t, mine), Entry{Name: "Code.StatusGone", Value: 410.0}))
+	if !report.Compatible() || report.Identical() {
+		t.Errorf("CompatibleWith(superset) = %v; want compatible, not identical", report)
+	}
+
+	if _, err := CompatibleWith(123, nil); err == nil {
+		t.Error("CompatibleWith(123) returned nil error")
+	}
+}
+
+// TestCompatible tests added, removed, and kind-changed members of two enum types.
+func TestCompatible(t *testing.T) {
+	type renamed struct {
+		Code struct {
+			StatusOK       int `enum:"201"`
+			StatusNotFound int `enum:"404"`
+		}
+		Label CodeLabel
+	}
+	if err := Compatible[CompatStatus, renamed](); err != nil {
+		t.Errorf("Compatible(same shape) = %v; want nil", err)
+	}
+
+	err := Compatible[CompatStatus, struct {
+		Code struct {
+			StatusOK     string
+			StatusTeapot int
+		}
+		Label CodeLabel
+	}]()
+	want := "incompatible enum types: added Code.StatusTeapot; removed Code.StatusNotFound; changed kind of Code.StatusOK (int -> string)"
+	if err == nil || err.Error() != want {
+		t.Errorf("Compatible() = %v; want %s", err, want)
+	}
+	if err := Compatible[CompatStatus, int](); err == nil {
+		t.Errorf("Compatible[CompatStatus, int]() = nil; want an error")
+	}
+}
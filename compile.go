@@ -0,0 +1,45 @@
+package enum
+
+// Compiled holds an initialized enum of type T together with precomputed lookup tables.
+// Its methods perform no reflection: each call is a slice or map index, which makes it
+// suitable for hot loops. A Compiled is immutable and safe for concurrent use; copying it
+// is cheap because the tables are shared.
+type Compiled[T any] struct {
+	enum T
+	idx  *index
+}
+
+// Compile initializes an enum of type T once and caches the names and values of its
+// leaf members. Nested members are named by their dotted path, e.g. "Code.StatusOK".
+// The options are passed to New, which panics under the usual conditions. Computed
+// values, such as those of WithIntDefault and WithStringDefault, are evaluated once here
+// rather than on access, since New must return a fully populated T.
+func Compile[T any](opts ...Option) Compiled[T] {
+	enum := New[T](opts...)
+	enumVal, _ := structValue(enum)
+	return Compiled[T]{enum: enum, idx: newIndex(enumVal)}
+}
+
+// New returns a copy of the cached enum instance.
+func (c Compiled[T]) New() T {
+	return c.enum
+}
+
+// Name returns the dotted name of the first member whose value equals v.
+// v must have the member's exact type, e.g. uint8(1) does not match an int member.
+func (c Compiled[T]) Name(v any) (string, bool) {
+	i, ok := c.idx.nameOf(v)
+	if !ok {
+		return "", false
+	}
+	return c.idx.entries[i].Name, true
+}
+
+// Value returns the value of the member with the given dotted name.
+func (c Compiled[T]) Value(name string) (any, bool) {
+	i, ok := c.idx.byName[name]
+	if !ok {
+		return nil, false
+	}
+	return c.idx.entries[i].Value, true
+}
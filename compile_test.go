@@ -0,0 +1,52 @@
+package enum
+
+import "testing"
+
+// TestCompile tests the cached New, Name, and Value lookups of a compiled nested enum.
+func TestCompile(t *testing.T) {
+	type HttpStatus struct {
+		Code struct {
+			StatusOK       int `enum:"200"`
+			StatusNotFound int `enum:"404"`
+		}
+		Type struct {
+			StatusOK string
+		}
+	}
+	c := Compile[HttpStatus]()
+
+	if got, want := c.New(), New[HttpStatus](); got != want {
+		t.Errorf("New() = %+v; want %+v", got, want)
+	}
+	if got, ok := c.Value("Code.StatusNotFound"); !ok || got != 404 {
+		t.Errorf("Value(%q) = %v, %v; want 404, true", "Code.StatusNotFound", got, ok)
+	}
+	if _, ok := c.Value("Code"); ok {
+		t.Errorf("Value(%q) found a group; want false", "Code")
+	}
+	if got, ok := c.Name(404); !ok || got != "Code.StatusNotFound" {
+		t.Errorf("Name(404) = %q, %v; want %q, true", got, ok, "Code.StatusNotFound")
+	}
+	if got, ok := c.Name("StatusOK"); !ok || got != "Type.StatusOK" {
+		t.Errorf("Name(%q) = %q, %v; want %q, true", "StatusOK", got, ok, "Type.StatusOK")
+	}
+	if _, ok := c.Name(uint8(200)); ok {
+		t.Errorf("Name(uint8(200)) found a member; want false")
+	}
+}
+
+// TestCompileNoAllocs tests that lookups on a compiled enum do not allocate.
+func TestCompileNoAllocs(t *testing.T) {
+	c := Compile[struct {
+		StatusOK       int `enum:"200"`
+		StatusNotFound int `enum:"404"`
+	}]()
+	var v any = 404
+	allocs := testing.AllocsPerRun(100, func() {
+		c.Value("StatusOK")
+		c.Name(v)
+	})
+	if allocs != 0 {
+		t.Errorf("lookups allocated %v times per run; want 0", allocs)
+	}
+}
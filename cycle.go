@@ -0,0 +1,34 @@
+package enum
+
+import "reflect"
+
+// Add treats the leaf members of enum with the same type as current as a cycle in
+// declaration order, such as the days of the week, and returns the value of the member
+// n positions after the one whose value equals current, wrapping around. Negative n
+// moves backwards. If several members share current's value, the first one is used.
+// Returns false if enum is not a struct or current is not the value of a member.
+func Add(enum any, current any, n int) (any, bool) {
+	enumVal, ok := structValue(enum)
+	if !ok {
+		return nil, false
+	}
+
+	var cycle []reflect.Value
+	position := -1
+	for _, m := range leaves(enumVal, "enum") {
+		if m.field.Type != reflect.TypeOf(current) {
+			continue
+		}
+		if position < 0 && m.value.Interface() == current {
+			position = len(cycle)
+		}
+		cycle = append(cycle, m.value)
+	}
+	if position < 0 {
+		return nil, false
+	}
+
+	// Reduce n first so that large steps cannot overflow the position.
+	i := (position + n%len(cycle) + len(cycle)) % len(cycle)
+	return cycle[i].Interface(), true
+}
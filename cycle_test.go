@@ -0,0 +1,44 @@
+package enum
+
+import "testing"
+
+// Weekday is the cyclic enum used by the Add tests.
+type Weekday struct {
+	Monday, Tuesday, Wednesday, Thursday, Friday, Saturday, Sunday int
+	Name                                                           string
+}
+
+// TestAdd tests forward, backward, and wrapping steps through a cycle.
+func TestAdd(t *testing.T) {
+	Days := New[Weekday](WithStartIndex(1))
+	tests := []struct {
+		current, n int
+		want       int
+	}{
+		{Days.Monday, 0, Days.Monday},
+		{Days.Monday, 1, Days.Tuesday},
+		{Days.Saturday, 2, Days.Monday},
+		{Days.Monday, -1, Days.Sunday},
+		{Days.Wednesday, -10, Days.Sunday},
+		{Days.Friday, 7, Days.Friday},
+		{Days.Tuesday, 7*1000 + 3, Days.Friday},
+	}
+	for _, tt := range tests {
+		if got, ok := Add(Days, tt.current, tt.n); !ok || got != tt.want {
+			t.Errorf("Add(%d, %d) = %v, %v; want %d, true", tt.current, tt.n, got, ok, tt.want)
+		}
+	}
+
+	if _, ok := Add(Days, 99, 1); ok {
+		t.Error("Add() of a non-member value returned true")
+	}
+	if _, ok := Add(Days, int64(1), 1); ok {
+		t.Error("Add() of a value of another type returned true")
+	}
+	if got, ok := Add(Days, "Name", 5); !ok || got != "Name" {
+		t.Errorf("Add(%q, 5) = %v, %v; want the only string member", "Name", got, ok)
+	}
+	if _, ok := Add(123, 1, 1); ok {
+		t.Error("Add() on a non-struct returned true")
+	}
+}
@@ -0,0 +1,90 @@
+package enum
+
+import "strings"
+
+// DisplayName returns the label of the leaf member of enum named member for locale, as
+// declared by "i18n.<locale>=<label>" tag options such as
+// `enum:"404,i18n.en=Not Found,i18n.de=Nicht gefunden"`. Locales match case-insensitively,
+// with "_" and "-" interchangeable. The label is looked up for the exact locale, then its
+// language alone ("de-AT" falls back to "de"), then the locale configured with
+// WithDefaultLocale, then the member's "display=" option, and finally defaults to the
+// member's field name. Returns false if enum is not a struct or has no such member.
+func DisplayName(enum any, member, locale string, opts ...Option) (string, bool) {
+	enumVal, ok := structValue(enum)
+	if !ok {
+		return "", false
+	}
+	o := buildOptions(opts)
+	m, err := resolveLeaf(enumVal, member, &o)
+	if err != nil {
+		return "", false
+	}
+
+	// Tags that fail to parse were rejected at initialization, so ignore the error.
+	_, options, _ := parseTag(m.field.Tag.Get(o.tagKey()))
+	labels := make(map[string]string)
+	for _, opt := range options {
+		if l := strings.TrimPrefix(opt.Key, "i18n."); l != opt.Key {
+			labels[normalizeLocale(l)] = opt.Value
+		}
+	}
+	for _, l := range []string{locale, language(locale), o.DefaultLocale, language(o.DefaultLocale)} {
+		if label, ok := labels[normalizeLocale(l)]; ok && l != "" {
+			return label, true
+		}
+	}
+	return displayOf(m, options), true
+}
+
+// Display returns the human-readable label of the leaf member of enum named name, as
+// declared by a "display=" tag option such as `enum:"not_found,display=Not Found"`,
+// falling back to the member's field name. Returns name unchanged if enum is not a
+// struct or has no such member.
+func Display(enum any, name string, opts ...Option) string {
+	enumVal, ok := structValue(enum)
+	if !ok {
+		return name
+	}
+	o := buildOptions(opts)
+	m, err := resolveLeaf(enumVal, name, &o)
+	if err != nil {
+		return name
+	}
+	_, options, _ := parseTag(m.field.Tag.Get(o.tagKey()))
+	return displayOf(m, options)
+}
+
+// DisplayNames returns the Display label of every leaf member of enum, keyed by dotted
+// name. Returns nil if enum is not a struct.
+func DisplayNames(enum any, opts ...Option) map[string]string {
+	enumVal, ok := structValue(enum)
+	if !ok {
+		return nil
+	}
+	o := buildOptions(opts)
+	names := make(map[string]string)
+	for _, m := range leaves(enumVal, o.tagKey()) {
+		_, options, _ := parseTag(m.field.Tag.Get(o.tagKey()))
+		names[m.path] = displayOf(m, options)
+	}
+	return names
+}
+
+// displayOf returns the "display=" option of a member, or its field name.
+func displayOf(m member, options []tagOption) string {
+	if display, ok := tagOptionValue(options, "display"); ok {
+		return display
+	}
+	return m.field.Name
+}
+
+// normalizeLocale returns the canonical form of a locale used for comparisons.
+func normalizeLocale(locale string) string {
+	return strings.ToLower(strings.ReplaceAll(locale, "_", "-"))
+}
+
+// language returns the language subtag of locale, e.g. "de" for "de-AT".
+func language(locale string) string {
+	lang, _, _ := strings.Cut(normalizeLocale(locale), "-")
+	return lang
+}
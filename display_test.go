@@ -0,0 +1,88 @@
+package enum
+
+import (
+	"reflect"
+	"testing"
+)
+
+// TestDisplayName tests the locale fallback chain of DisplayName.
+func TestDisplayName(t *testing.T) {
+	Status := New[struct {
+		Code struct {
+			NotFound int    `enum:"404,i18n.en=Not Found,i18n.de=Nicht gefunden,i18n.de-AT=Ned gfundn"`
+			Teapot   int    `enum:"418,i18n.en-GB=I'm a teapot"`
+			OK       string `enum:"ok,i18n.fr=D'accord\\, super"`
+		}
+	}]()
+	if Status.Code.NotFound != 404 || Status.Code.OK != "ok" {
+		t.Fatalf("tag options leaked into values: %+v", Status)
+	}
+
+	tests := []struct {
+		member, locale string
+		opts           []Option
+		want           string
+	}{
+		{"Code.NotFound", "de-AT", nil, "Ned gfundn"},
+		{"Code.NotFound", "de_at", nil, "Ned gfundn"},
+		{"Code.NotFound", "de-CH", nil, "Nicht gefunden"},
+		{"Code.NotFound", "EN", nil, "Not Found"},
+		{"Code.NotFound", "ja", []Option{WithDefaultLocale("en")}, "Not Found"},
+		{"Code.Teapot", "ja", []Option{WithDefaultLocale("en-GB")}, "I'm a teapot"},
+		{"Code.Teapot", "ja", nil, "Teapot"},
+		{"Code.OK", "fr-FR", nil, "D'accord, super"},
+	}
+	for _, tt := range tests {
+		if got, ok := DisplayName(Status, tt.member, tt.locale, tt.opts...); !ok || got != tt.want {
+			t.Errorf("DisplayName(%q, %q) = %q, %v; want %q, true", tt.member, tt.locale, got, ok, tt.want)
+		}
+	}
+	if _, ok := DisplayName(Status, "Code.Missing", "en"); ok {
+		t.Error("DisplayName() of an unknown member returned true")
+	}
+	if _, ok := DisplayName(123, "Code", "en"); ok {
+		t.Error("DisplayName() of a non-struct returned true")
+	}
+
+	if _, err := TryNew[struct {
+		A int `enum:"1,i18n.=x"`
+	}](); err == nil {
+		t.Error("TryNew() with an invalid i18n option returned nil error")
+	}
+}
+
+// TestDisplay tests display= options, their fallback, and Format honoring them.
+func TestDisplay(t *testing.T) {
+	Status := New[struct {
+		NotFound string `enum:"not_found,display=Not Found"`
+		Teapot   string `enum:"teapot,display=I'm a teapot,i18n.de=Ich bin eine Teekanne"`
+		Gone     string `enum:"gone"`
+	}]()
+	if Status.NotFound != "not_found" {
+		t.Fatalf("Status.NotFound = %q; want %q", Status.NotFound, "not_found")
+	}
+
+	if got := Display(Status, "NotFound"); got != "Not Found" {
+		t.Errorf("Display(%q) = %q; want %q", "NotFound", got, "Not Found")
+	}
+	if got := Display(Status, "Gone"); got != "Gone" {
+		t.Errorf("Display(%q) = %q; want %q", "Gone", got, "Gone")
+	}
+	if got := Display(Status, "Missing"); got != "Missing" {
+		t.Errorf("Display(%q) = %q; want %q", "Missing", got, "Missing")
+	}
+	want := map[string]string{"NotFound": "Not Found", "Teapot": "I'm a teapot", "Gone": "Gone"}
+	if got := DisplayNames(Status); !reflect.DeepEqual(got, want) {
+		t.Errorf("DisplayNames() = %v; want %v", got, want)
+	}
+	if got, _ := DisplayName(Status, "Teapot", "fr"); got != "I'm a teapot" {
+		t.Errorf("DisplayName(%q, %q) = %q; want display fallback %q", "Teapot", "fr", got, "I'm a teapot")
+	}
+
+	wantFormat := "Not Found    = \"not_found\"\n" +
+		"I'm a teapot = \"teapot\"\n" +
+		"Gone         = \"gone\"\n"
+	if got := Format(Status, WithDisplayNames()); got != wantFormat {
+		t.Errorf("Format(WithDisplayNames()) =\n%s\nwant\n%s", got, wantFormat)
+	}
+}
@@ -1,9 +1,18 @@
 package enum
 
 import (
+	"errors"
 	"fmt"
+	"math"
+	"os"
 	"reflect"
+	"regexp"
+	"sort"
 	"strconv"
+	"strings"
+	"text/template"
+
+	"github.com/tnnmigga/enum/internal/tagrule"
 )
 
 // Package enum provides a generic mechanism to initialize enumeration-like structs in Go.
@@ -37,212 +46,963 @@ import (
 
 // New initializes an enum instance of type T, which must be a struct.
 // Fields are populated based on their names (for strings), indices (for integers),
-// or values specified in the "enum" tag. Supports nested structs, which are initialized
-// recursively. Pointer fields are not allowed. Panics if T is not a struct, if unsupported
+// or values specified in the "enum" tag. An integer field with no tag, or an empty
+// one, takes its index among the fields of its own struct, counting groups and fields
+// of other types but not marker or sentinel fields, so indices restart at zero in
+// each nested group. String tags may embed the tokens "%name" and
+// "%index", which expand to the field name and index; write "%%" for a literal "%".
+// Supports nested structs, which are initialized recursively, except for code-label
+// structs (see CodeLabel) which are filled as a single member.
+// Pointer fields are not allowed, except *regexp.Regexp members, which are compiled from
+// their mandatory tag. Panics if T is not a struct, if unsupported
 // field types (including pointers) are used, or if integer values overflow the target field type.
-func New[T any]() T {
+// Options such as WithTagKey adjust the initialization; see Option.
+func New[T any](opts ...Option) T {
+	enum, err := TryNew[T](opts...)
+	if err != nil {
+		panic(err.Error())
+	}
+	return enum
+}
+
+// TryNew initializes an enum instance of type T like New, but returns an error
+// describing the first invalid field instead of panicking.
+func TryNew[T any](opts ...Option) (T, error) {
+	return NewWith[T](buildOptions(opts))
+}
+
+// Recover calls fn, typically func() T { return New[T]() }, and converts a panic in it
+// into an error, so that existing New call sites can gain error handling without
+// switching to TryNew. A panic value that is an error is returned as is; any other value
+// becomes the error's message.
+func Recover[T any](fn func() T) (result T, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			var zero T
+			result = zero
+			if e, ok := r.(error); ok {
+				err = e
+			} else {
+				err = fmt.Errorf("%v", r)
+			}
+		}
+	}()
+	return fn(), nil
+}
+
+// NewUnchecked initializes an enum instance of type T like New but skips the integer
+// overflow checks, as WithWrapOverflow does, for definitions whose ranges are already
+// known to be valid, such as generated code. The caller is trusted: a value that does
+// not fit its field is silently truncated rather than reported.
+func NewUnchecked[T any](opts ...Option) T {
+	return New[T](append(opts[:len(opts):len(opts)], WithWrapOverflow())...)
+}
+
+// NewWith initializes an enum instance of type T like New, configured by opts.
+// Instead of panicking it returns an error describing the first invalid field, or
+// naming the options set in opts that only apply to queries, such as WithDedup.
+func NewWith[T any](opts Options) (T, error) {
 	var enum T
 	enumVal := reflect.ValueOf(&enum).Elem()
 	enumType := reflect.TypeOf(&enum).Elem()
 
 	// Initialize the struct recursively.
-	initialize(enumVal, enumType)
-	return enum
+	in := &initializer{opts: opts}
+	if err := in.run(enumVal, enumType); err != nil {
+		var zero T
+		return zero, err
+	}
+	return enum, nil
+}
+
+// NewGroups initializes only the named top-level groups of T, and everything nested in
+// them, leaving all other fields zeroed. This avoids initializing parts of a large enum
+// that a code path does not use. Returns an error if a name does not refer to a nested
+// struct field of T, or under the same conditions as NewWith.
+func NewGroups[T any](groups ...string) (T, error) {
+	var zero T
+	enumType := reflect.TypeOf(&zero).Elem()
+	if enumType.Kind() != reflect.Struct {
+		return zero, fmt.Errorf("type %s is not a struct", enumType)
+	}
+
+	// Validate the requested group names against the type.
+	wanted := make(map[string]bool, len(groups))
+	for _, name := range groups {
+		field, ok := enumType.FieldByName(name)
+		if !ok || len(field.Index) != 1 {
+			return zero, fmt.Errorf("group %s does not exist", name)
+		}
+		if !isGroup(field.Type) {
+			return zero, fmt.Errorf("field %s is not a group", name)
+		}
+		wanted[name] = true
+	}
+
+	// Admit the named top-level groups and everything beneath them.
+	return NewWith[T](Options{FieldFilter: func(path string, field reflect.StructField) bool {
+		return path != field.Name || wanted[field.Name]
+	}})
+}
+
+// NewFromTemplate initializes an enum instance of type T like New, executing every tag
+// that contains "{{" as a text/template against data and using the output as the tag.
+// Other tags pass through unchanged. Panics with the field name if a template fails to
+// parse or execute, and under the same conditions as New.
+func NewFromTemplate[T any](data any, opts ...Option) T {
+	return New[T](append(opts[:len(opts):len(opts)], WithTemplateData(data))...)
+}
+
+// NewWithTranslator initializes an enum instance of type T like New, taking the value of
+// each untagged string member, and the label of each untagged code-label member, from
+// tr called with the field name, such as a lookup in a message catalog. Tagged members
+// keep their tags. It is New with WithStringDefault(tr), which takes precedence over a
+// naming style in opts; a panic in tr is reported like any other invalid field.
+func NewWithTranslator[T any](tr func(name string) string, opts ...Option) T {
+	return New[T](append(opts[:len(opts):len(opts)], WithStringDefault(tr))...)
+}
+
+// ValidateType runs the checks New performs on a dynamic type: t must be a struct whose
+// fields are supported and whose tags parse and fit their fields. It returns the error
+// New would panic with, or nil if t is a valid enum type. Frameworks that discover enum
+// types via reflection can validate them before constructing instances.
+func ValidateType(t reflect.Type, opts ...Option) error {
+	if t == nil {
+		return fmt.Errorf("type is nil")
+	}
+	in := &initializer{opts: buildOptions(opts)}
+	return in.run(reflect.New(t).Elem(), t)
+}
+
+// IsEnumType reports whether ValidateType accepts t.
+func IsEnumType(t reflect.Type, opts ...Option) bool {
+	return ValidateType(t, opts...) == nil
+}
+
+// initializer carries the options of a single New call through the recursion.
+type initializer struct {
+	opts Options
+
+	// overridden records the Overrides paths that matched a member.
+	overridden map[string]bool
+
+	// renamed records the Renames paths that matched a member.
+	renamed map[string]bool
+
+	// group holds the options inherited from the tags of the enclosing groups.
+	group groupOptions
+
+	// parent is the struct holding the group being initialized, and groupIndex the
+	// group's field index in it, for resolving "same=" tags. parent is invalid at the
+	// root.
+	parent     reflect.Value
+	groupIndex int
+
+	// leafIndex is the index of the next non-group field across the whole enum, used as
+	// the default index if ContinuousIndex is set.
+	leafIndex int
+}
+
+// run initializes the root struct val of type typ and performs the checks that need
+// the whole enum, such as reporting overrides that matched no member.
+func (in *initializer) run(val reflect.Value, typ reflect.Type) error {
+	in.overridden = make(map[string]bool)
+	in.renamed = make(map[string]bool)
+	if names := in.opts.queryOnly(); len(names) > 0 {
+		return fmt.Errorf("options %s do not apply to initialization", strings.Join(names, ", "))
+	}
+	if typ.Kind() != reflect.Struct {
+		return fmt.Errorf("type %s is not a struct", typ)
+	}
+	if err := in.initialize(val, typ, ""); err != nil {
+		return qualify(typ, err)
+	}
+
+	// Report override and rename paths that do not name a member, so typos are caught.
+	if unknown := unmatched(in.opts.Overrides, in.overridden); len(unknown) > 0 {
+		return qualify(typ, fmt.Errorf("unknown override paths: %s", strings.Join(unknown, ", ")))
+	}
+	if unknown := unmatched(in.opts.Renames, in.renamed); len(unknown) > 0 {
+		return qualify(typ, fmt.Errorf("unknown rename paths: %s", strings.Join(unknown, ", ")))
+	}
+	return nil
+}
+
+// qualify prefixes err with the package-qualified name of the enum type typ, such as
+// "type mypkg.HttpStatus field Code.StatusOK: ...", so that errors identify their enum in
+// codebases with many of them. Errors of anonymous struct types are returned unchanged.
+func qualify(typ reflect.Type, err error) error {
+	if typ.Name() == "" {
+		return err
+	}
+	return fmt.Errorf("type %s %w", typ, err)
+}
+
+// unmatched returns the sorted keys of m that are not marked in matched.
+func unmatched[V any](m map[string]V, matched map[string]bool) []string {
+	var keys []string
+	for key := range m {
+		if !matched[key] {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+	return keys
 }
 
 // initialize recursively initializes a struct, handling its fields and nested structs.
-// Panics on errors, such as non-struct types, unsupported field types, invalid tags,
+// path is the separator-joined path of the struct within the enum, empty for the root.
+// Returns an error on non-struct types, unsupported field types, invalid tags,
 // or integer overflows.
-func initialize(val reflect.Value, typ reflect.Type) {
+//
+// A blank marker field "_ struct{}" whose tag holds options such as
+// "start=100,step=10,case=snake,strict" configures its struct and, unless they carry
+// their own marker, its nested groups. Its options override those passed to New for the
+// keys it sets.
+//
+// A nested group's tag may hold "default=<tag>", which is used as the tag of the group's
+// untagged members, including those of groups nested in it, that it parses as: an
+// integer member keeps its index under "default=unknown". It may also hold
+// "prefix=<prefix>", which is prepended to the group's untagged string members, or to
+// all of them if the tag also holds "tagged". Prefixes of nested groups compose from the
+// outside in. Likewise "offset=<n>" is added to the group's integer members, tagged ones
+// included unless the tag also holds "absolute", and offsets of nested groups add up.
+// "base=<n>" makes the group's untagged integer members count from n instead of the
+// start index, as a marker's "start=" would; groups nested in it count from n too unless
+// they set their own base. Unknown "key=value" options fail initialization, while group
+// tags that hold no options, such as "group1", are ignored.
+//
+// A field of type []Entry tagged "entries" is a sentinel rather than a member: it is
+// filled with the entries of the leaf members of its struct, named relative to it, in
+// declaration order, and is skipped by the default index and the listing functions.
+// Likewise an integer field tagged "count" is filled with the number of value-bearing
+// members of its struct, not counting nested groups, sentinels, or filtered fields.
+//
+// A member tagged "@name:Member", such as "@http:StatusNotFound", copies the value of
+// the member of the enum registered under name with Register, converted and checked
+// like a SetField value. Likewise a member tagged "same=Group" copies the value of the
+// member with its field name in Group, a sibling of its own group declared before it,
+// and the untagged members of a group tagged "same=Code" do so from Code, so that the
+// group mirrors Code except for its tagged members. A backslash keeps such tags literal:
+// a string member tagged `enum:"\\@user:name"` holds the value "@user:name". The value of a member is thus taken from, in order of precedence:
+// an override, the member's own tag, the default tag or mirrored group of the nearest
+// enclosing group that has one, and finally the options.
+func (in *initializer) initialize(val reflect.Value, typ reflect.Type, path string) error {
 	// Ensure the type is a struct.
 	if typ.Kind() != reflect.Struct {
-		panic(fmt.Sprintf("type %s is not a struct", typ))
+		return fmt.Errorf("type %s is not a struct", typ)
+	}
+
+	// Apply the configuration of marker fields to this struct and its nested groups.
+	for i := 0; i < typ.NumField(); i++ {
+		if field := typ.Field(i); isMarker(field, in.opts.tagKey()) {
+			tag := field.Tag.Get(in.opts.tagKey())
+			outer := in.opts
+			defer func() { in.opts = outer }()
+			if err := applyMarker(&in.opts, tag); err != nil {
+				return fmt.Errorf("field %s: invalid marker: %v", in.opts.join(path, field.Name), err)
+			}
+		}
 	}
 
+	// Track sibling values per type for duplicate detection in strict mode.
+	var seen map[reflect.Type]map[any]string
+	if in.opts.Strict {
+		seen = make(map[reflect.Type]map[any]string)
+	}
+
+	// Count the value-bearing fields for the default value callbacks.
+	position := 0
+
+	// Track the external names of renamed fields to detect collisions.
+	var renamedTo map[string]string
+
+	// Count the marker and sentinel fields, which do not take part in the default index.
+	skipped := 0
+
+	// Collect the entries and count sentinels, filled once their siblings are initialized.
+	var sentinels []reflect.Value
+	var counts []member
+
 	// Iterate over all fields of the struct.
 	for i := 0; i < val.NumField(); i++ {
 		fieldVal := val.Field(i)
 		fieldType := typ.Field(i)
+		fieldPath := in.opts.join(path, fieldType.Name)
+
+		// Skip marker fields, so that the first member after one gets index 0.
+		if isMarker(fieldType, in.opts.tagKey()) {
+			skipped++
+			continue
+		}
+
+		// Set entries and count sentinels aside, likewise without shifting the index.
+		if isCount(fieldType, in.opts.tagKey()) {
+			skipped++
+			if fieldVal.CanSet() {
+				counts = append(counts, member{path: fieldPath, field: fieldType, value: fieldVal})
+			}
+			continue
+		}
+		if fieldType.Type == entriesType {
+			skipped++
+			if tag := fieldType.Tag.Get(in.opts.tagKey()); tag != "entries" {
+				return fmt.Errorf("field %s: entries sentinels must be tagged \"entries\", not %q", fieldPath, tag)
+			}
+			if fieldVal.CanSet() {
+				sentinels = append(sentinels, fieldVal)
+			}
+			continue
+		}
+		fieldIndex := i - skipped
+		if in.opts.ContinuousIndex && !isGroup(fieldType.Type) {
+			fieldIndex = in.leafIndex
+			in.leafIndex++
+		}
 
 		// Skip unexported fields that cannot be set.
 		if !fieldVal.CanSet() {
 			continue
 		}
 
-		// Get the "enum" tag, if present.
-		tagVal := fieldType.Tag.Get("enum")
+		// Skip fields rejected by the caller's filter, leaving them zeroed.
+		if in.opts.FieldFilter != nil && !in.opts.FieldFilter(fieldPath, fieldType) {
+			continue
+		}
+
+		// Resolve the external name of a renamed field, rejecting collisions with the
+		// names of other fields or the external names of earlier renamed fields.
+		name := fieldType.Name
+		if alias, ok := in.opts.Renames[fieldPath]; ok {
+			if other, ok := typ.FieldByName(alias); ok && other.Name != fieldType.Name && len(other.Index) == 1 {
+				return fmt.Errorf("field %s: rename to %s collides with field %s", fieldPath, alias, other.Name)
+			}
+			if other, ok := renamedTo[alias]; ok {
+				return fmt.Errorf("field %s: rename to %s collides with renamed field %s", fieldPath, alias, other)
+			}
+			if renamedTo == nil {
+				renamedTo = make(map[string]string)
+			}
+			renamedTo[alias] = fieldType.Name
+			in.renamed[fieldPath] = true
+			name = alias
+		}
+
+		// Get the enum tag, if present, and expand it.
+		tagVal, err := in.expandTag(fieldType.Tag.Get(in.opts.tagKey()), isPattern(fieldType.Type))
+		if err != nil {
+			return fmt.Errorf("field %s: %v", fieldPath, err)
+		}
 
 		// Handle field based on its type.
 		fieldKind := fieldType.Type.Kind()
 
-		// Check for disallowed pointer types.
-		if fieldKind == reflect.Ptr {
-			panic(fmt.Sprintf("field %s: pointer types are not supported", fieldType.Name))
+		// Check for disallowed pointer types, other than compiled patterns.
+		if fieldKind == reflect.Ptr && !isPattern(fieldType.Type) {
+			return fmt.Errorf("field %s: pointer types are not supported", fieldPath)
 		}
 
-		// Handle nested structs recursively.
-		if fieldKind == reflect.Struct {
-			initialize(fieldVal, fieldType.Type)
+		// Handle nested structs recursively, passing down the options of their tag.
+		if isGroup(fieldType.Type) {
+			group, err := parseGroupTag(tagVal)
+			if err != nil {
+				return fmt.Errorf("field %s: invalid group tag: %v", fieldPath, err)
+			}
+			outer, outerStart, outerParent, outerIndex := in.group, in.opts.StartIndex, in.parent, in.groupIndex
+			if in.group, err = outer.inherit(group); err != nil {
+				return fmt.Errorf("field %s: invalid group tag: %v", fieldPath, err)
+			}
+			if group.hasBase {
+				in.opts.StartIndex = group.base
+			}
+			in.parent, in.groupIndex = val, i
+			err = in.initialize(fieldVal, fieldType.Type, fieldPath)
+			in.group, in.opts.StartIndex, in.parent, in.groupIndex = outer, outerStart, outerParent, outerIndex
+			if err != nil {
+				return err
+			}
 			continue
 		}
 
-		// Handle basic types (string or integer).
-		switch fieldKind {
-		case reflect.String:
-			// Use field name as default value, or tag if provided.
-			value := fieldType.Name
-			if tagVal != "" {
-				value = tagVal
-			}
-			fieldVal.SetString(value)
-
-		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
-			// Use field index as default value, or parse tag if provided.
-			value := int64(i)
-			if tagVal != "" {
-				parsedVal, err := strconv.ParseInt(tagVal, 10, 64)
-				if err != nil {
-					panic(fmt.Sprintf("field %s: invalid enum tag: %v", fieldType.Name, err))
+		// Split off the options following the value, such as "i18n.en=Not Found".
+		tagVal, _, err = parseTag(tagVal)
+		if err != nil {
+			return fmt.Errorf("field %s: invalid enum tag: %v", fieldPath, err)
+		}
+		// A value "@name:Member" references a member of a registered enum, and a value
+		// "same=Group" mirrors the member of the same name in Group; a backslash before
+		// either keeps it literal.
+		ref, isRef := tagVal, tagrule.IsReference(tagVal)
+		same, isSame := tagrule.Mirror(tagVal)
+		if isSame && same == "" {
+			return fmt.Errorf("field %s: invalid enum tag: same= names no group", fieldPath)
+		}
+		if isRef || isSame {
+			tagVal = ""
+		}
+		tagVal = tagrule.Literal(tagVal)
+
+		// Use the default tag, or the mirrored group, of the enclosing groups for
+		// untagged members.
+		if tagVal == "" && !isRef && !isSame {
+			if in.group.hasDefault {
+				if defaultFits(in.group.defaultTag, fieldKind) {
+					tagVal = in.group.defaultTag
 				}
-				value = parsedVal
+			} else if in.group.same != "" {
+				same, isSame = in.group.same, true
 			}
-			// Check for integer overflow.
-			if err := checkIntOverflow(value, fieldKind); err != nil {
-				panic(fmt.Sprintf("field %s: %v", fieldType.Name, err))
+		}
+
+		// Handle basic types (string or integer).
+		index := position
+		position++
+		// Copy the value of a member of a registered enum for "@name:Member" tags,
+		// or derive the value from the tag or the defaults.
+		if isRef {
+			if err := resolveReference(fieldVal, ref); err != nil {
+				return fmt.Errorf("field %s: %v", fieldPath, err)
 			}
-			fieldVal.SetInt(value)
+		} else if isSame {
+			if err := in.resolveSame(fieldVal, fieldType.Name, same); err != nil {
+				return fmt.Errorf("field %s: same=%s: %v", fieldPath, same, err)
+			}
+		} else {
+			switch fieldKind {
+			case reflect.String:
+				// Use field name as default value, or tag if provided.
+				value := expandTokens(tagVal, fieldType.Name, fieldIndex)
+				if tagVal == "" {
+					defaultVal, err := in.stringDefault(name)
+					if err != nil {
+						return fmt.Errorf("field %s: %v", fieldPath, err)
+					}
+					value = defaultVal
+				}
+				// Apply the prefixes of the enclosing groups, then decorate default values,
+				// and tagged ones if requested.
+				value = in.group.prefix(tagVal != "") + value
+				if tagVal == "" || in.opts.DecorateTagged {
+					value = in.opts.ValuePrefix + value + in.opts.ValueSuffix
+				}
+				fieldVal.SetString(value)
+
+			case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+				// Parse the tag if provided, or use the field index as default value.
+				var value int64
+				if tagVal != "" {
+					if value, err = tagrule.ParseInt(tagVal); err != nil {
+						return fmt.Errorf("field %s: invalid enum tag: %v", fieldPath, err)
+					}
+				} else if value, err = in.intDefault(fieldIndex, index, fieldType.Name); err != nil {
+					return fmt.Errorf("field %s: %v", fieldPath, err)
+				}
+				// Check for integer overflow, of the offset sum and of the field.
+				if !in.opts.WrapOverflow {
+					if value, err = addOffset(value, in.group.offset(tagVal != "")); err != nil {
+						return fmt.Errorf("field %s: %v", fieldPath, err)
+					}
+					if err := checkIntOverflow(value, fieldKind); err != nil {
+						return fmt.Errorf("field %s: %v", fieldPath, err)
+					}
+				} else {
+					value += in.group.offset(tagVal != "")
+				}
+				fieldVal.SetInt(value)
+
+			case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+				// Use field index as default value, or parse tag if provided.
+				var value uint64
+				if tagVal == "" {
+					defaultVal, err := in.intDefault(fieldIndex, index, fieldType.Name)
+					if err != nil {
+						return fmt.Errorf("field %s: %v", fieldPath, err)
+					}
+					if !in.opts.WrapOverflow {
+						if defaultVal, err = addOffset(defaultVal, in.group.offset(false)); err != nil {
+							return fmt.Errorf("field %s: %v", fieldPath, err)
+						}
+					} else {
+						defaultVal += in.group.offset(false)
+					}
+					if defaultVal < 0 {
+						return fmt.Errorf("field %s: default value %d is negative", fieldPath, defaultVal)
+					}
+					value = uint64(defaultVal)
+				} else {
+					parsedVal, err := tagrule.ParseUint(tagVal)
+					if err != nil && in.opts.WrapSigned && strings.HasPrefix(tagVal, "-") {
+						parsedVal, err = wrapSigned(tagVal, fieldType.Type)
+					}
+					if err != nil {
+						return fmt.Errorf("field %s: invalid enum tag: %v", fieldPath, err)
+					}
+					offset := in.group.offset(true)
+					if offset < 0 && uint64(-offset) > parsedVal {
+						return fmt.Errorf("field %s: value %d with offset %d is negative", fieldPath, parsedVal, offset)
+					}
+					if offset > 0 && parsedVal > math.MaxUint64-uint64(offset) && !in.opts.WrapOverflow {
+						return fmt.Errorf("field %s: value %d with offset %d overflows uint64", fieldPath, parsedVal, offset)
+					}
+					value = parsedVal + uint64(offset)
+				}
+				// Check for unsigned integer overflow.
+				if !in.opts.WrapOverflow {
+					if err := checkUintOverflow(value, fieldKind); err != nil {
+						return fmt.Errorf("field %s: %v", fieldPath, err)
+					}
+				}
+				fieldVal.SetUint(value)
+
+			case reflect.Struct:
+				// Fill a range from its mandatory "lo-hi" tag.
+				if isRange(fieldType.Type) {
+					if tagVal == "" {
+						return fmt.Errorf("field %s: range members must be tagged", fieldPath)
+					}
+					r, err := parseRange(tagVal)
+					if err != nil {
+						return fmt.Errorf("field %s: invalid enum tag: %v", fieldPath, err)
+					}
+					fieldVal.Set(reflect.ValueOf(r))
+					break
+				}
+				// Fill a code-label pair from a "code:label" tag, or from the defaults.
+				var code int64
+				var label string
+				if tagVal != "" {
+					if code, label, err = tagrule.ParseCodeLabel(tagVal); err != nil {
+						return fmt.Errorf("field %s: invalid enum tag: %v", fieldPath, err)
+					}
+				} else {
+					if code, err = in.intDefault(fieldIndex, index, name); err != nil {
+						return fmt.Errorf("field %s: %v", fieldPath, err)
+					}
+					if label, err = in.stringDefault(name); err != nil {
+						return fmt.Errorf("field %s: %v", fieldPath, err)
+					}
+				}
+				if err := setCodeLabel(fieldVal, code, label); err != nil {
+					return fmt.Errorf("field %s: %v", fieldPath, err)
+				}
 
-		case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
-			// Use field index as default value, or parse tag if provided.
-			value := uint64(i)
-			if tagVal != "" {
-				parsedVal, err := strconv.ParseUint(tagVal, 10, 64)
+			case reflect.Ptr:
+				// Compile a pattern from its mandatory tag.
+				if tagVal == "" {
+					return fmt.Errorf("field %s: pattern members must be tagged", fieldPath)
+				}
+				re, err := regexp.Compile(tagVal)
 				if err != nil {
-					panic(fmt.Sprintf("field %s: invalid enum tag: %v", fieldType.Name, err))
+					return fmt.Errorf("field %s: invalid pattern: %v", fieldPath, err)
 				}
-				value = parsedVal
+				fieldVal.Set(reflect.ValueOf(re))
+
+			default:
+				return fmt.Errorf("field %s: unsupported type %s; only string, integer, or struct types are allowed", fieldPath, fieldKind)
 			}
-			// Check for unsigned integer overflow.
-			if err := checkUintOverflow(value, fieldKind); err != nil {
-				panic(fmt.Sprintf("field %s: %v", fieldType.Name, err))
+		}
+
+		// Apply an override for this member, replacing the tagged or default value.
+		if override, ok := in.opts.Overrides[fieldPath]; ok {
+			if err := assign(fieldVal, override); err != nil {
+				return fmt.Errorf("field %s: override: %v", fieldPath, err)
 			}
-			fieldVal.SetUint(value)
+			in.overridden[fieldPath] = true
+		}
 
-		default:
-			panic(fmt.Sprintf("field %s: unsupported type %s; only string, integer, or struct types are allowed", fieldType.Name, fieldKind))
+		// Reject values already taken by a sibling of the same type in strict mode.
+		if seen != nil {
+			values := seen[fieldType.Type]
+			if values == nil {
+				values = make(map[any]string)
+				seen[fieldType.Type] = values
+			}
+			value := fieldVal.Interface()
+			if other, ok := values[value]; ok {
+				return fmt.Errorf("field %s: duplicate value %v already used by %s", fieldPath, value, other)
+			}
+			values[value] = fieldType.Name
 		}
-	}
-}
 
-// checkIntOverflow verifies if the value fits within the range of the specified signed integer type.
-// Returns an error if the value overflows; used to trigger a panic in the caller.
-func checkIntOverflow(value int64, kind reflect.Kind) error {
-	switch kind {
-	case reflect.Int8:
-		if value < -1<<7 || value > 1<<7-1 {
-			return fmt.Errorf("value %d overflows int8 range [-128, 127]", value)
+		// Report the final value to the caller's hook.
+		if in.opts.FieldHook != nil {
+			if err := in.callHook(fieldPath, fieldType, fieldVal.Interface()); err != nil {
+				return fmt.Errorf("field %s: %v", fieldPath, err)
+			}
 		}
-	case reflect.Int16:
-		if value < -1<<15 || value > 1<<15-1 {
-			return fmt.Errorf("value %d overflows int16 range [-32768, 32767]", value)
+	}
+
+	// Fill the count sentinels with the number of value-bearing members of this struct.
+	for _, count := range counts {
+		if err := assign(count.value, position); err != nil {
+			return fmt.Errorf("field %s: count: %v", count.path, err)
 		}
-	case reflect.Int32:
-		if value < -1<<31 || value > 1<<31-1 {
-			return fmt.Errorf("value %d overflows int32 range [-2147483648, 2147483647]", value)
+	}
+
+	// Fill the entries sentinels with the leaf members of this struct.
+	if len(sentinels) > 0 {
+		entries := entriesOf(val, in.opts.tagKey())
+		for _, sentinel := range sentinels {
+			sentinel.Set(reflect.ValueOf(append([]Entry(nil), entries...)))
 		}
-	case reflect.Int, reflect.Int64:
-		// No additional check needed for int/int64, as value is already int64.
 	}
 	return nil
 }
 
-// checkUintOverflow verifies if the value fits within the range of the specified unsigned integer type.
-// Returns an error if the value overflows; used to trigger a panic in the caller.
-func checkUintOverflow(value uint64, kind reflect.Kind) error {
-	switch kind {
-	case reflect.Uint8:
-		if value > 1<<8-1 {
-			return fmt.Errorf("value %d overflows uint8 range [0, 255]", value)
+// resolveSame copies into field the value of the member name of the sibling group of
+// the group being initialized, which must be declared before it.
+func (in *initializer) resolveSame(field reflect.Value, name, sibling string) error {
+	if !in.parent.IsValid() {
+		return fmt.Errorf("member is not in a group")
+	}
+	groupField, ok := in.parent.Type().FieldByName(sibling)
+	if !ok || len(groupField.Index) != 1 || !groupField.IsExported() || !isGroup(groupField.Type) {
+		return fmt.Errorf("no group %s", sibling)
+	}
+	if groupField.Index[0] >= in.groupIndex {
+		return fmt.Errorf("group %s must be declared before %s", sibling, in.parent.Type().Field(in.groupIndex).Name)
+	}
+	m, ok := findLeaf(in.parent.Field(groupField.Index[0]), name, in.opts.tagKey())
+	if !ok {
+		return fmt.Errorf("group %s has no member %s", sibling, name)
+	}
+	return assign(field, m.value.Interface())
+}
+
+// stringDefault returns the value of an untagged string field.
+func (in *initializer) stringDefault(name string) (value string, err error) {
+	if in.opts.StringDefault == nil {
+		return in.opts.NamingStyle.apply(name), nil
+	}
+	defer recoverCallback(&err)
+	return in.opts.StringDefault(name), nil
+}
+
+// intDefault returns the value of an untagged integer field declared at index i,
+// which is the position-th value-bearing field of its struct.
+func (in *initializer) intDefault(i, position int, name string) (value int64, err error) {
+	if in.opts.IntDefault == nil {
+		return in.opts.indexValue(i), nil
+	}
+	defer recoverCallback(&err)
+	return in.opts.IntDefault(position, name), nil
+}
+
+// callHook calls the field hook with a member's path, kind, raw tag, and value.
+func (in *initializer) callHook(path string, field reflect.StructField, value any) (err error) {
+	defer recoverCallback(&err)
+	in.opts.FieldHook(path, field.Type.Kind(), field.Tag.Get(in.opts.tagKey()), value)
+	return nil
+}
+
+// recoverCallback converts a panic in a caller-supplied callback into an error.
+// It must be deferred directly.
+func recoverCallback(err *error) {
+	if r := recover(); r != nil {
+		*err = fmt.Errorf("callback panicked: %v", r)
+	}
+}
+
+// defaultFits reports whether a group's default tag applies to a member of the given
+// kind: integer members take it only if it parses as an integer of their signedness.
+func defaultFits(tag string, kind reflect.Kind) bool {
+	switch {
+	case isSignedKind(kind):
+		_, err := tagrule.ParseInt(tag)
+		return err == nil
+	case isIntegerKind(kind):
+		_, err := tagrule.ParseUint(tag)
+		return err == nil
+	}
+	return true
+}
+
+// expandTag applies the configured tag expansions to a raw tag value. Environment
+// variables are not expanded in the tags of pattern members.
+func (in *initializer) expandTag(tag string, pattern bool) (string, error) {
+	if in.opts.TemplateData != nil && strings.Contains(tag, "{{") {
+		tmpl, err := template.New("enum").Option("missingkey=error").Parse(tag)
+		if err != nil {
+			return "", fmt.Errorf("invalid tag template: %v", err)
 		}
-	case reflect.Uint16:
-		if value > 1<<16-1 {
-			return fmt.Errorf("value %d overflows uint16 range [0, 65535]", value)
+		var buf strings.Builder
+		if err := tmpl.Execute(&buf, in.opts.TemplateData); err != nil {
+			return "", fmt.Errorf("tag template: %v", err)
 		}
-	case reflect.Uint32:
-		if value > 1<<32-1 {
-			return fmt.Errorf("value %d overflows uint32 range [0, 4294967295]", value)
+		tag = buf.String()
+	}
+	if in.opts.EnvExpansion && !pattern && strings.Contains(tag, "$") {
+		var unset []string
+		tag = os.Expand(tag, func(name string) string {
+			// os.Expand reads "$$" as the special variable "$", which escapes a dollar.
+			if name == "$" {
+				return "$"
+			}
+			value, ok := os.LookupEnv(name)
+			if !ok {
+				unset = append(unset, name)
+			}
+			return value
+		})
+		if in.opts.StrictEnv && len(unset) > 0 {
+			return "", fmt.Errorf("unset environment variables: %s", strings.Join(unset, ", "))
 		}
-	case reflect.Uint, reflect.Uint64:
-		// No additional check needed for uint/uint64, as value is already uint64.
 	}
-	return nil
+	return tag, nil
+}
+
+// expandTokens replaces the tokens of a string tag: "%name" becomes the field name,
+// "%index" the field index, and "%%" a literal percent sign. Any other percent sign is
+// kept as is, so tags without tokens are unchanged.
+func expandTokens(tag, name string, index int) string {
+	if !strings.Contains(tag, "%") {
+		return tag
+	}
+
+	var buf strings.Builder
+	for i := 0; i < len(tag); i++ {
+		rest := tag[i:]
+		switch {
+		case strings.HasPrefix(rest, "%%"):
+			buf.WriteByte('%')
+			i++
+		case strings.HasPrefix(rest, "%name"):
+			buf.WriteString(name)
+			i += len("%name") - 1
+		case strings.HasPrefix(rest, "%index"):
+			buf.WriteString(strconv.Itoa(index))
+			i += len("%index") - 1
+		default:
+			buf.WriteByte(tag[i])
+		}
+	}
+	return buf.String()
+}
+
+// addOffset adds a group offset to the integer value of a member. Returns an error if
+// the sum overflows int64.
+func addOffset(value, offset int64) (int64, error) {
+	sum := value + offset
+	if (offset > 0 && sum < value) || (offset < 0 && sum > value) {
+		return 0, fmt.Errorf("value %d with offset %d overflows int64", value, offset)
+	}
+	return sum, nil
+}
+
+// checkIntOverflow verifies that value fits the signed integer kind.
+// Returns an error if the value overflows; used to trigger a panic in the caller.
+func checkIntOverflow(value int64, kind reflect.Kind) error {
+	return tagrule.CheckInt(value, kind)
+}
+
+// checkUintOverflow verifies that value fits the unsigned integer kind.
+// Returns an error if the value overflows; used to trigger a panic in the caller.
+func checkUintOverflow(value uint64, kind reflect.Kind) error {
+	return tagrule.CheckUint(value, kind)
+}
+
+// wrapSigned parses a negative tag for an unsigned field of type typ as a signed integer
+// of the same width and returns its two's complement bit pattern, e.g. 255 for "-1" and
+// uint8. Returns an error if the value is below the signed range of the width.
+func wrapSigned(tag string, typ reflect.Type) (uint64, error) {
+	value, err := strconv.ParseInt(tag, 10, 64)
+	if err != nil {
+		return 0, err
+	}
+	bits := typ.Bits()
+	if bits < 64 && value < -1<<(bits-1) {
+		return 0, fmt.Errorf("value %d overflows the signed range of %d-bit %s", value, bits, typ)
+	}
+	if bits < 64 {
+		return uint64(value) & (1<<bits - 1), nil
+	}
+	return uint64(value), nil
 }
 
 // Contains checks if the enum has a top-level field with the same type and value as the provided value.
-// It does not recursively check nested structs. Returns true if a matching field is found, false otherwise.
-func Contains[T enumerable](enum any, value T) bool {
-	enumVal := reflect.ValueOf(enum)
-	if enumVal.Kind() != reflect.Struct {
+// It does not recursively check nested structs unless WithNested is given, in which case
+// every leaf member is checked. With WithRanges, an integer value also matches a Range
+// member containing it. Returns true if a matching field is found, false otherwise.
+func Contains[T enumerable](enum any, value T, opts ...Option) bool {
+	enumVal, ok := structValue(enum)
+	if !ok {
 		return false
 	}
 
 	valueType := reflect.TypeOf(value)
-	for i := 0; i < enumVal.NumField(); i++ {
-		fieldVal := enumVal.Field(i)
-		if !fieldVal.CanInterface() {
-			continue
-		}
-
-		fieldType := enumVal.Type().Field(i).Type
-		if fieldType == valueType && reflect.DeepEqual(fieldVal.Interface(), value) {
+	for _, m := range fieldsOf(enumVal, opts) {
+		if m.field.Type == valueType && reflect.DeepEqual(m.value.Interface(), value) {
 			return true
 		}
 	}
-	return false
+	return buildOptions(opts).Ranges && inRange(fieldsOf(enumVal, opts), reflect.ValueOf(value))
 }
 
 // Keys returns a slice of the names of all top-level fields in the enum.
-// It does not include fields from nested structs or unexported fields.
-func Keys(enum any) []string {
-	enumVal := reflect.ValueOf(enum)
-	if enumVal.Kind() != reflect.Struct {
+// It does not include fields from nested structs or unexported fields. With WithNested,
+// it returns the dotted paths of the leaf members instead, such as "Code.StatusOK".
+// Members are listed in the order selected by WithOrder, declaration order by default;
+// members that cannot be ordered by value are listed in declaration order, and KeysE
+// reports the error instead.
+func Keys(enum any, opts ...Option) []string {
+	enumVal, ok := structValue(enum)
+	if !ok {
 		return nil
 	}
+	keys, _ := keysOf(enumVal, opts)
+	return keys
+}
 
+// keysOf returns the names Keys lists for the struct value val, and the error, if any,
+// that kept them in declaration order.
+func keysOf(val reflect.Value, opts []Option) ([]string, error) {
+	o := buildOptions(opts)
+	members, err := sortedFields(fieldsOf(val, opts), opts)
 	var keys []string
-	for i := 0; i < enumVal.NumField(); i++ {
-		if enumVal.Field(i).CanInterface() {
-			keys = append(keys, enumVal.Type().Field(i).Name)
-		}
+	for _, m := range members {
+		keys = append(keys, o.outputName(m.path))
 	}
-	return keys
+	return keys, err
 }
 
 // Values returns a slice of the values of all top-level fields in the enum that match the type T.
-// T must be an integer or string type. It does not include values from nested structs or unexported fields.
-func Values[T enumerable](enum any) []T {
-	enumVal := reflect.ValueOf(enum)
-	if enumVal.Kind() != reflect.Struct {
+// T must be an integer or string type. It does not include values from nested structs or
+// unexported fields, unless WithNested is given, in which case the leaf members are used.
+// Values are listed in the order selected by WithOrder, declaration order by default.
+func Values[T enumerable](enum any, opts ...Option) []T {
+	enumVal, ok := structValue(enum)
+	if !ok {
 		return nil
 	}
 
-	var values []T
+	var members []member
 	targetType := reflect.TypeOf((*T)(nil)).Elem()
-	for i := 0; i < enumVal.NumField(); i++ {
-		fieldVal := enumVal.Field(i)
-		if !fieldVal.CanInterface() {
-			continue
+	for _, m := range fieldsOf(enumVal, opts) {
+		if m.value.Type() == targetType {
+			members = append(members, m)
 		}
+	}
 
-		if fieldVal.Type() == targetType {
-			values = append(values, fieldVal.Interface().(T))
-		}
+	// Members of a single string or integer type can always be ordered.
+	members, _ = sortedFields(members, opts)
+	var values []T
+	for _, m := range members {
+		values = append(values, m.value.Interface().(T))
 	}
 	return values
 }
 
+// KeysValues returns the names and values of the top-level fields in the enum whose type
+// is V, as parallel slices: keys[i] is the name of the field holding values[i].
+// Keys and Values called separately can disagree on indices for enums that mix field
+// types, because Keys lists every field while Values filters by type; callers that need
+// aligned slices should use KeysValues instead. WithNested selects leaf members as Keys does,
+// and WithOrder sorts the pairs, leaving them in declaration order if they cannot be
+// ordered by value.
+func KeysValues[V any](enum any, opts ...Option) ([]string, []V) {
+	enumVal, ok := structValue(enum)
+	if !ok {
+		return nil, nil
+	}
+
+	var members []member
+	for _, m := range fieldsOf(enumVal, opts) {
+		if _, ok := m.value.Interface().(V); ok {
+			members = append(members, m)
+		}
+	}
+
+	o := buildOptions(opts)
+	members, _ = sortedFields(members, opts)
+	var keys []string
+	var values []V
+	for _, m := range members {
+		keys = append(keys, o.outputName(m.path))
+		values = append(values, m.value.Interface().(V))
+	}
+	return keys, values
+}
+
+// KeysE is Keys returning an error, rather than nil, if enum is not a struct, and rather
+// than falling back to declaration order if the members cannot be ordered by value.
+func KeysE(enum any, opts ...Option) ([]string, error) {
+	if err := checkStruct(enum); err != nil {
+		return nil, err
+	}
+	enumVal, _ := structValue(enum)
+	keys, err := keysOf(enumVal, opts)
+	if err != nil {
+		return nil, err
+	}
+	return keys, nil
+}
+
+// ValuesE is Values returning an error, rather than nil, if enum is not a struct.
+func ValuesE[T enumerable](enum any, opts ...Option) ([]T, error) {
+	if err := checkStruct(enum); err != nil {
+		return nil, err
+	}
+	return Values[T](enum, opts...), nil
+}
+
+// ContainsE is Contains returning an error, rather than false, if enum is not a struct.
+func ContainsE[T enumerable](enum any, value T, opts ...Option) (bool, error) {
+	if err := checkStruct(enum); err != nil {
+		return false, err
+	}
+	return Contains(enum, value, opts...), nil
+}
+
+// checkStruct returns an error describing what enum holds instead of a struct or a
+// non-nil pointer to one, or nil.
+func checkStruct(enum any) error {
+	enumVal := reflect.ValueOf(enum)
+	switch {
+	case !enumVal.IsValid():
+		return errors.New("enum is nil, not a struct")
+	case enumVal.Kind() == reflect.Ptr && enumVal.IsNil():
+		return fmt.Errorf("enum is a nil %s, not a struct", enumVal.Type())
+	case enumVal.Kind() == reflect.Ptr && enumVal.Elem().Kind() != reflect.Struct:
+		return fmt.Errorf("enum is a pointer of type %s, not a struct", enumVal.Type())
+	case enumVal.Kind() != reflect.Struct && enumVal.Kind() != reflect.Ptr:
+		return fmt.Errorf("enum is of kind %s, not a struct (type %s)", enumVal.Kind(), enumVal.Type())
+	}
+	return nil
+}
+
+// sortedFields sorts members in the order selected by opts. If they cannot be ordered as
+// requested, it returns them in declaration order with the error.
+func sortedFields(members []member, opts []Option) ([]member, error) {
+	o := buildOptions(opts)
+	return members, sortMembers(members, o.Order)
+}
+
+// fieldsOf returns the exported top-level fields of the struct value val, or its leaf
+// members if opts include WithNested.
+func fieldsOf(val reflect.Value, opts []Option) []member {
+	o := buildOptions(opts)
+	if o.Nested {
+		return leaves(val, o.tagKey())
+	}
+
+	var members []member
+	for i := 0; i < val.NumField(); i++ {
+		if fieldVal := val.Field(i); fieldVal.CanInterface() {
+			field := val.Type().Field(i)
+			if isSentinel(field, o.tagKey()) {
+				continue
+			}
+			members = append(members, member{path: field.Name, field: field, value: fieldVal})
+		}
+	}
+	return members
+}
+
 type enumerable interface {
+	integer | ~string
+}
+
+type integer interface {
 	~int | ~int8 | ~int16 | ~int32 | ~int64 |
-		~uint | ~uint8 | ~uint16 | ~uint32 | ~uint64 |
-		~string
+		~uint | ~uint8 | ~uint16 | ~uint32 | ~uint64
 }
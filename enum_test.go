@@ -1,7 +1,10 @@
 package enum
 
 import (
+	"errors"
+	"fmt"
 	"reflect"
+	"strings"
 	"testing"
 )
 
@@ -108,3 +111,1081 @@ func TestValues(t *testing.T) {
 		t.Errorf("Values[int](%v) = %v; want []", HttpStatus, got)
 	}
 }
+
+// DeepStatus is an enum nested five levels deep.
+type DeepStatus struct {
+	Top int `enum:"1"`
+	A   struct {
+		B struct {
+			Name string
+			C    struct {
+				D struct {
+					StatusOK       int `enum:"200"`
+					StatusNotFound int `enum:"404"`
+					E              struct {
+						Label string `enum:"deepest"`
+					}
+				}
+				After int
+			}
+		}
+	}
+	Last string
+}
+
+// TestDeepNesting tests paths, order, and values of an enum nested five levels deep.
+func TestDeepNesting(t *testing.T) {
+	Status := New[DeepStatus]()
+	if Status.A.B.C.D.StatusOK != 200 || Status.A.B.C.D.E.Label != "deepest" ||
+		Status.A.B.Name != "Name" || Status.A.B.C.After != 1 || Status.Last != "Last" {
+		t.Errorf("New[DeepStatus]() = %+v; values did not propagate", Status)
+	}
+
+	wantKeys := []string{"Top", "A.B.Name", "A.B.C.D.StatusOK", "A.B.C.D.StatusNotFound", "A.B.C.D.E.Label", "A.B.C.After", "Last"}
+	if got := Keys(Status, WithNested()); !reflect.DeepEqual(got, wantKeys) {
+		t.Errorf("Keys(WithNested()) = %v; want %v", got, wantKeys)
+	}
+	if got, want := Keys(Status), []string{"Top", "A", "Last"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("Keys() = %v; want %v", got, want)
+	}
+	if got, want := Values[int](Status, WithNested()), []int{1, 200, 404, 1}; !reflect.DeepEqual(got, want) {
+		t.Errorf("Values[int](WithNested()) = %v; want %v", got, want)
+	}
+	if !Contains(Status, "deepest", WithNested()) || Contains(Status, "deepest") {
+		t.Errorf("Contains(%q) should only find the deepest member with WithNested()", "deepest")
+	}
+	if got, err := Parse[int](Status, "A.B.C.D.StatusNotFound"); err != nil || got != 404 {
+		t.Errorf("Parse(%q) = %d, %v; want 404, nil", "A.B.C.D.StatusNotFound", got, err)
+	}
+
+	_, err := TryNew[struct {
+		A struct {
+			B struct {
+				C struct {
+					D struct {
+						Bad int `enum:"x"`
+					}
+				}
+			}
+		}
+	}]()
+	if err == nil || !strings.Contains(err.Error(), "field A.B.C.D.Bad") {
+		t.Errorf("TryNew() error = %v; want error naming A.B.C.D.Bad", err)
+	}
+}
+
+// TestNewGroups tests that only the named groups are initialized.
+func TestNewGroups(t *testing.T) {
+	type HttpStatus struct {
+		Code struct {
+			StatusOK int `enum:"200"`
+			Detail   struct {
+				Retry int `enum:"5"`
+			}
+		}
+		Type struct {
+			StatusOK string
+		}
+		Name string
+	}
+	got, err := NewGroups[HttpStatus]("Code")
+	if err != nil {
+		t.Fatalf("NewGroups returned error: %v", err)
+	}
+	if got.Code.StatusOK != 200 || got.Code.Detail.Retry != 5 {
+		t.Errorf("got Code %+v, want {StatusOK: 200, Detail: {Retry: 5}}", got.Code)
+	}
+	if got.Type.StatusOK != "" || got.Name != "" {
+		t.Errorf("got Type %+v and Name %q, want zero values", got.Type, got.Name)
+	}
+
+	if _, err := NewGroups[HttpStatus]("Missing"); err == nil {
+		t.Errorf("NewGroups(%q) returned nil error; want error", "Missing")
+	}
+	if _, err := NewGroups[HttpStatus]("Name"); err == nil {
+		t.Errorf("NewGroups(%q) returned nil error; want error", "Name")
+	}
+}
+
+// TestNewFromTemplate tests template tags for string and integer fields.
+func TestNewFromTemplate(t *testing.T) {
+	data := map[string]any{"Version": "v2", "Port": 8080}
+	got := NewFromTemplate[struct {
+		Topic  string `enum:"orders.{{.Version}}"`
+		Port   int    `enum:"{{.Port}}"`
+		Plain  string `enum:"plain"`
+		Linked string
+	}](data)
+	if got.Topic != "orders.v2" || got.Port != 8080 || got.Plain != "plain" || got.Linked != "Linked" {
+		t.Errorf("got %+v, want {Topic: orders.v2, Port: 8080, Plain: plain, Linked: Linked}", got)
+	}
+
+	// The caller's options must not be overwritten through spare capacity.
+	opts := make([]Option, 1, 2)
+	opts[0] = WithNamingStyle(NamingLower)
+	sentinel := WithNamingStyle(NamingUpper)
+	opts = append(opts, sentinel)[:1]
+	NewFromTemplate[struct{ Topic string }](data, opts...)
+	if o := buildOptions(opts[:2]); o.NamingStyle != NamingUpper {
+		t.Error("NewFromTemplate() overwrote the spare capacity of the caller's options")
+	}
+}
+
+// TestNewWithTranslator tests translated defaults, tagged members, and code labels.
+func TestNewWithTranslator(t *testing.T) {
+	catalog := map[string]string{"NotFound": "Introuvable", "Teapot": "Théière", "Tagged": "unused"}
+	got := NewWithTranslator[struct {
+		NotFound string
+		Tagged   string `enum:"tagged"`
+		Teapot   CodeLabel
+		Missing  string
+	}](func(name string) string { return catalog[name] })
+	if got.NotFound != "Introuvable" || got.Tagged != "tagged" || got.Teapot.Label != "Théière" || got.Missing != "" {
+		t.Errorf("got %+v, want {Introuvable tagged {2 Théière} \"\"}", got)
+	}
+}
+
+// TestNewFromTemplateErrors tests that template failures panic with the field name.
+func TestNewFromTemplateErrors(t *testing.T) {
+	assertPanics := func(name string, fn func()) {
+		t.Helper()
+		defer func() {
+			if r := recover(); r == nil || !strings.Contains(fmt.Sprint(r), name) {
+				t.Errorf("panic = %v; want panic mentioning %s", r, name)
+			}
+		}()
+		fn()
+	}
+	assertPanics("Broken", func() {
+		NewFromTemplate[struct {
+			Broken string `enum:"{{.Version"`
+		}](map[string]any{})
+	})
+	assertPanics("Missing", func() {
+		NewFromTemplate[struct {
+			Missing string `enum:"{{.Version}}"`
+		}](map[string]any{})
+	})
+}
+
+// TestValidateType tests validating dynamic types without constructing them.
+func TestValidateType(t *testing.T) {
+	valid := reflect.TypeOf(struct {
+		Code struct {
+			StatusOK int `enum:"200"`
+		}
+		Name string
+	}{})
+	if err := ValidateType(valid); err != nil || !IsEnumType(valid) {
+		t.Errorf("ValidateType(%v) = %v; want nil", valid, err)
+	}
+
+	invalid := []reflect.Type{
+		nil,
+		reflect.TypeOf(0),
+		reflect.TypeOf(struct{ P *int }{}),
+		reflect.TypeOf(struct {
+			Small int8 `enum:"1000"`
+		}{}),
+	}
+	for _, typ := range invalid {
+		if err := ValidateType(typ); err == nil || IsEnumType(typ) {
+			t.Errorf("ValidateType(%v) = nil; want error", typ)
+		}
+	}
+}
+
+// TestStringTagTokens tests the %name, %index, and %% tokens in string tags.
+func TestStringTagTokens(t *testing.T) {
+	got := New[struct {
+		StatusOK       string `enum:"prefix_%name_suffix"`
+		StatusNotFound string `enum:"%index:%name"`
+		Percent        string `enum:"100%% of %name"`
+		Literal        string `enum:"50% off"`
+	}]()
+	if got.StatusOK != "prefix_StatusOK_suffix" {
+		t.Errorf("got StatusOK %q, want %q", got.StatusOK, "prefix_StatusOK_suffix")
+	}
+	if got.StatusNotFound != "1:StatusNotFound" {
+		t.Errorf("got StatusNotFound %q, want %q", got.StatusNotFound, "1:StatusNotFound")
+	}
+	if got.Percent != "100% of Percent" {
+		t.Errorf("got Percent %q, want %q", got.Percent, "100% of Percent")
+	}
+	if got.Literal != "50% off" {
+		t.Errorf("got Literal %q, want %q", got.Literal, "50% off")
+	}
+}
+
+// TestNewPanics tests that malformed enum definitions panic with a message naming the
+// offending field and describing the problem.
+func TestNewPanics(t *testing.T) {
+	tests := []struct {
+		name  string
+		build func()
+		field string
+		want  string
+	}{
+		{"pointer", func() { New[struct{ Ptr *int }]() }, "Ptr", "pointer types are not supported"},
+		{"interface", func() { New[struct{ Iface any }]() }, "Iface", "unsupported type interface"},
+		{"func", func() { New[struct{ Fn func() }]() }, "Fn", "unsupported type func"},
+		{"chan", func() { New[struct{ Ch chan int }]() }, "Ch", "unsupported type chan"},
+		{"map", func() { New[struct{ M map[string]int }]() }, "M", "unsupported type map"},
+		{"int8 overflow", func() {
+			New[struct {
+				Small int8 `enum:"128"`
+			}]()
+		}, "Small", "overflows int8"},
+		{"negative uint", func() {
+			New[struct {
+				Count uint `enum:"-1"`
+			}]()
+		}, "Count", "invalid enum tag"},
+		{"non-numeric int", func() {
+			New[struct {
+				Code int `enum:"abc"`
+			}]()
+		}, "Code", "invalid enum tag"},
+		{"nested path", func() {
+			New[struct {
+				Group struct{ Ptr *int }
+			}]()
+		}, "Group.Ptr", "pointer types are not supported"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			defer func() {
+				r := recover()
+				if r == nil {
+					t.Fatal("New did not panic")
+				}
+				msg := fmt.Sprint(r)
+				if !strings.Contains(msg, "field "+tt.field+":") || !strings.Contains(msg, tt.want) {
+					t.Errorf("panic = %q; want it to name field %s and contain %q", msg, tt.field, tt.want)
+				}
+			}()
+			tt.build()
+		})
+	}
+}
+
+// TestKeysValues tests that KeysValues returns aligned slices for a mixed enum.
+func TestKeysValues(t *testing.T) {
+	Mixed := New[struct {
+		Name    string
+		Code    int `enum:"200"`
+		Label   string
+		Retries int `enum:"3"`
+	}]()
+
+	keys, values := KeysValues[int](Mixed)
+	if !reflect.DeepEqual(keys, []string{"Code", "Retries"}) || !reflect.DeepEqual(values, []int{200, 3}) {
+		t.Errorf("KeysValues[int](%v) = %v, %v; want [Code Retries], [200 3]", Mixed, keys, values)
+	}
+	keys, values2 := KeysValues[string](Mixed)
+	if !reflect.DeepEqual(keys, []string{"Name", "Label"}) || !reflect.DeepEqual(values2, []string{"Name", "Label"}) {
+		t.Errorf("KeysValues[string](%v) = %v, %v; want [Name Label], [Name Label]", Mixed, keys, values2)
+	}
+	if keys, values := KeysValues[int](123); keys != nil || values != nil {
+		t.Errorf("KeysValues[int](123) = %v, %v; want nil, nil", keys, values)
+	}
+}
+
+// TestOverflowAliasNames tests that overflow messages mention the byte and rune aliases.
+func TestOverflowAliasNames(t *testing.T) {
+	_, err := TryNew[struct {
+		B byte `enum:"300"`
+	}]()
+	if err == nil || !strings.Contains(err.Error(), "value 300 overflows uint8 (byte) range") {
+		t.Errorf("TryNew error = %v; want overflow message mentioning byte", err)
+	}
+	_, err = TryNew[struct {
+		R rune `enum:"3000000000"`
+	}]()
+	if err == nil || !strings.Contains(err.Error(), "overflows int32 (rune) range") {
+		t.Errorf("TryNew error = %v; want overflow message mentioning rune", err)
+	}
+}
+
+// TestMarkerField tests struct-level configuration through a blank marker field.
+func TestMarkerField(t *testing.T) {
+	type Status struct {
+		_        struct{} `enum:"start=100,step=10,case=snake"`
+		Active   int
+		Inactive int
+		Name     string
+		Group    struct {
+			_         struct{} `enum:"start=1,case=screaming_snake"`
+			First     int
+			SecondOne string
+		}
+		Inherited struct {
+			Third     int
+			FourthOne string
+		}
+	}
+	got := New[Status]()
+	if got.Active != 100 || got.Inactive != 110 || got.Name != "name" {
+		t.Errorf("marker configuration not applied: %+v", got)
+	}
+	if got.Group.First != 1 || got.Group.SecondOne != "SECOND_ONE" {
+		t.Errorf("nested marker did not override: %+v", got.Group)
+	}
+	if got.Inherited.Third != 100 || got.Inherited.FourthOne != "fourth_one" {
+		t.Errorf("nested group did not inherit marker: %+v", got.Inherited)
+	}
+
+	_, err := TryNew[struct {
+		_ struct{} `enum:"strict"`
+		A int      `enum:"1"`
+		B int      `enum:"1"`
+	}]()
+	if err == nil {
+		t.Error("TryNew() with a strict marker and duplicate values returned nil error")
+	}
+
+	_, err = TryNew[struct {
+		_ struct{} `enum:"start=1,colour=red"`
+		A int
+	}]()
+	if err == nil || !strings.Contains(err.Error(), `unknown option "colour"`) {
+		t.Errorf("TryNew() error = %v; want unknown option error", err)
+	}
+}
+
+// TestGroupDefaultTag tests that group default tags reach untagged leaves only.
+func TestGroupDefaultTag(t *testing.T) {
+	got, err := TryNew[struct {
+		Top    string
+		Reason struct {
+			Timeout string
+			Denied  string `enum:"access denied"`
+			Inner   struct {
+				Lost string
+			}
+			Other struct {
+				Gone string
+			} `enum:"default=%name (other)"`
+		} `enum:"default=unknown"`
+		Codes struct {
+			A int
+			B int `enum:"7"`
+		} `enum:"default=-1"`
+	}](WithOverrides(map[string]any{"Reason.Inner.Lost": "found"}))
+	if err != nil {
+		t.Fatalf("TryNew() error = %v", err)
+	}
+	if got.Top != "Top" || got.Reason.Timeout != "unknown" || got.Reason.Denied != "access denied" ||
+		got.Reason.Inner.Lost != "found" || got.Reason.Other.Gone != "Gone (other)" {
+		t.Errorf("string defaults = %+v, %+v", got.Top, got.Reason)
+	}
+	if got.Codes.A != -1 || got.Codes.B != 7 {
+		t.Errorf("integer defaults = %+v; want {A:-1 B:7}", got.Codes)
+	}
+
+	_, err = TryNew[struct {
+		Group struct{ A string } `enum:"fallback=x"`
+	}]()
+	if err == nil || !strings.Contains(err.Error(), `field Group: invalid group tag: unknown option "fallback"`) {
+		t.Errorf("TryNew() error = %v; want unknown group option error", err)
+	}
+
+	// A default applies only to the members it parses as, and tags without options are
+	// ignored.
+	mixed, err := TryNew[struct {
+		Mixed struct {
+			Reason   string
+			Code     int
+			Unsigned uint8
+		} `enum:"default=unknown"`
+		Signed struct {
+			Neg      int
+			Unsigned uint
+		} `enum:"default=-1"`
+		Labeled struct {
+			A string
+		} `enum:"group1"`
+	}]()
+	if err != nil {
+		t.Fatalf("TryNew() error = %v", err)
+	}
+	if mixed.Mixed.Reason != "unknown" || mixed.Mixed.Code != 1 || mixed.Mixed.Unsigned != 2 {
+		t.Errorf("Mixed = %+v; want {Reason:unknown Code:1 Unsigned:2}", mixed.Mixed)
+	}
+	if mixed.Signed.Neg != -1 || mixed.Signed.Unsigned != 1 {
+		t.Errorf("Signed = %+v; want {Neg:-1 Unsigned:1}", mixed.Signed)
+	}
+	if mixed.Labeled.A != "A" {
+		t.Errorf("Labeled.A = %q; want the tag ignored", mixed.Labeled.A)
+	}
+}
+
+// TestGroupPrefix tests group prefixes on default and tagged members and their composition.
+func TestGroupPrefix(t *testing.T) {
+	got := New[struct {
+		Top    string
+		Events struct {
+			Click  string
+			Custom string `enum:"custom"`
+			User   struct {
+				Login  string
+				Logout string `enum:"bye"`
+			} `enum:"prefix=user.,tagged"`
+		} `enum:"prefix=evt."`
+		Tagged struct {
+			Open string `enum:"open"`
+		} `enum:"prefix=tag., tagged"`
+	}](WithValuePrefix("app."))
+
+	tests := []struct {
+		got, want string
+	}{
+		{got.Top, "app.Top"},
+		{got.Events.Click, "app.evt.Click"},
+		{got.Events.Custom, "custom"},
+		{got.Events.User.Login, "app.evt.user.Login"},
+		{got.Events.User.Logout, "user.bye"},
+		{got.Tagged.Open, "tag.open"},
+	}
+	for _, tt := range tests {
+		if tt.got != tt.want {
+			t.Errorf("member = %q; want %q", tt.got, tt.want)
+		}
+	}
+
+	if _, err := TryNew[struct {
+		Group struct{ A string } `enum:"tagged"`
+	}](); err == nil {
+		t.Error("TryNew() with tagged but no prefix returned nil error")
+	}
+}
+
+// TestGroupOffset tests offsets inherited two levels deep, the absolute flag, and overflow.
+func TestGroupOffset(t *testing.T) {
+	got := New[struct {
+		Top      int
+		Requests struct {
+			Get    int
+			Post   int
+			Tagged int `enum:"50"`
+			Admin  struct {
+				Reset  uint16
+				Pinned uint16 `enum:"7"`
+			} `enum:"offset=100,absolute"`
+		} `enum:"offset=1000"`
+	}]()
+	if got.Top != 0 || got.Requests.Get != 1000 || got.Requests.Post != 1001 || got.Requests.Tagged != 1050 {
+		t.Errorf("offset members = %+v; want 1000, 1001, 1050", got.Requests)
+	}
+	if got.Requests.Admin.Reset != 1100 || got.Requests.Admin.Pinned != 1007 {
+		t.Errorf("nested offset members = %+v; want {Reset:1100 Pinned:1007}", got.Requests.Admin)
+	}
+
+	overflows := []struct {
+		name string
+		typ  any
+		want string
+	}{
+		{"int64", struct {
+			G struct {
+				A int64 `enum:"9223372036854775807"`
+			} `enum:"offset=1"`
+		}{}, "field G.A: value 9223372036854775807 with offset 1 overflows int64"},
+		{"negative int64", struct {
+			G struct {
+				A int64 `enum:"-9223372036854775808"`
+			} `enum:"offset=-1"`
+		}{}, "overflows int64"},
+		{"uint64", struct {
+			G struct {
+				A uint64 `enum:"18446744073709551615"`
+			} `enum:"offset=1"`
+		}{}, "field G.A: value 18446744073709551615 with offset 1 overflows uint64"},
+		{"summed offsets", struct {
+			G struct {
+				H struct {
+					A int
+				} `enum:"offset=9223372036854775807"`
+			} `enum:"offset=1"`
+		}{}, "field G.H: invalid group tag: value 1 with offset 9223372036854775807 overflows int64"},
+	}
+	for _, tt := range overflows {
+		if err := ValidateType(reflect.TypeOf(tt.typ)); err == nil || !strings.Contains(err.Error(), tt.want) {
+			t.Errorf("%s: ValidateType() error = %v; want error containing %q", tt.name, err, tt.want)
+		}
+	}
+
+	defer func() {
+		if r := recover(); r == nil || !strings.Contains(fmt.Sprint(r), "field Outer.Inner.B") {
+			t.Errorf("New() panic = %v; want overflow error naming Outer.Inner.B", r)
+		}
+	}()
+	New[struct {
+		Outer struct {
+			Inner struct {
+				A int8
+				B int8
+			} `enum:"offset=27"`
+		} `enum:"offset=100"`
+	}]()
+}
+
+// TestGroupBase tests group bases, their inheritance, and their interplay with tags.
+func TestGroupBase(t *testing.T) {
+	got := New[struct {
+		OK           int
+		ClientErrors struct {
+			BadRequest   int
+			Unauthorized int
+			Teapot       int `enum:"418"`
+			Legacy       struct {
+				Gone uint16
+			}
+		} `enum:"base=400"`
+		ServerErrors struct {
+			Internal       int
+			NotImplemented int
+		} `enum:"base=500,offset=1"`
+		Redirects struct {
+			Moved int
+		} `enum:"base=300"`
+	}]()
+	if got.OK != 0 || got.ClientErrors.BadRequest != 400 || got.ClientErrors.Unauthorized != 401 || got.ClientErrors.Teapot != 418 {
+		t.Errorf("ClientErrors = %+v; want 400, 401, 418", got.ClientErrors)
+	}
+	if got.ClientErrors.Legacy.Gone != 400 {
+		t.Errorf("ClientErrors.Legacy.Gone = %d; want the inherited base 400", got.ClientErrors.Legacy.Gone)
+	}
+	if got.ServerErrors.Internal != 501 || got.ServerErrors.NotImplemented != 502 || got.Redirects.Moved != 300 {
+		t.Errorf("ServerErrors = %+v, Redirects = %+v; want 501, 502, 300", got.ServerErrors, got.Redirects)
+	}
+
+	if _, err := TryNew[struct {
+		G struct{ A int } `enum:"base=x"`
+	}](); err == nil || !strings.Contains(err.Error(), `invalid option "base=x"`) {
+		t.Errorf("TryNew() with invalid base error = %v", err)
+	}
+}
+
+// TestNewUnchecked tests that overflowing values are truncated instead of panicking.
+func TestNewUnchecked(t *testing.T) {
+	got := NewUnchecked[struct {
+		A int8  `enum:"300"`
+		B uint8 `enum:"7"`
+	}]()
+	if got.A != 44 || got.B != 7 {
+		t.Errorf("NewUnchecked() = %+v; want {A:44 B:7}", got)
+	}
+}
+
+// BenchStatus is a wide integer enum used to measure the cost of overflow checks.
+type BenchStatus struct {
+	A, B, C, D, E, F, G, H int8
+	I, J, K, L, M, N, O, P uint16
+	Q, R, S, T, U, V, W, X int32
+	Code                   struct{ Y, Z, AA, AB int64 }
+}
+
+// BenchmarkNew measures New, which checks every integer for overflow.
+func BenchmarkNew(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		New[BenchStatus]()
+	}
+}
+
+// BenchmarkNewUnchecked measures NewUnchecked, which skips the overflow checks.
+func BenchmarkNewUnchecked(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		NewUnchecked[BenchStatus]()
+	}
+}
+
+// TestOrder tests Keys, Values, KeysValues, and Entries under every order.
+func TestOrder(t *testing.T) {
+	Priority := New[struct {
+		Medium int `enum:"5"`
+		Low    int `enum:"-1"`
+		Urgent int `enum:"10"`
+		High   int `enum:"7"`
+		Group  struct {
+			Backlog int `enum:"-3"`
+		}
+	}]()
+
+	tests := []struct {
+		order  Order
+		keys   []string
+		values []int
+	}{
+		{ByDeclaration, []string{"Medium", "Low", "Urgent", "High", "Group.Backlog"}, []int{5, -1, 10, 7, -3}},
+		{ByName, []string{"Group.Backlog", "High", "Low", "Medium", "Urgent"}, []int{-3, 7, -1, 5, 10}},
+		{ByValue, []string{"Group.Backlog", "Low", "Medium", "High", "Urgent"}, []int{-3, -1, 5, 7, 10}},
+		{ByValueDesc, []string{"Urgent", "High", "Medium", "Low", "Group.Backlog"}, []int{10, 7, 5, -1, -3}},
+	}
+	for _, tt := range tests {
+		opts := []Option{WithNested(), WithOrder(tt.order)}
+		if got := Keys(Priority, opts...); !reflect.DeepEqual(got, tt.keys) {
+			t.Errorf("order %d: Keys() = %v; want %v", tt.order, got, tt.keys)
+		}
+		if got := Values[int](Priority, opts...); !reflect.DeepEqual(got, tt.values) {
+			t.Errorf("order %d: Values() = %v; want %v", tt.order, got, tt.values)
+		}
+		if keys, values := KeysValues[int](Priority, opts...); !reflect.DeepEqual(keys, tt.keys) || !reflect.DeepEqual(values, tt.values) {
+			t.Errorf("order %d: KeysValues() = %v, %v; want %v, %v", tt.order, keys, values, tt.keys, tt.values)
+		}
+		var names []string
+		var values []int
+		for _, e := range Entries(Priority, WithOrder(tt.order)) {
+			names = append(names, e.Name)
+			values = append(values, e.Value.(int))
+		}
+		if !reflect.DeepEqual(names, tt.keys) || !reflect.DeepEqual(values, tt.values) {
+			t.Errorf("order %d: Entries() = %v, %v; want %v, %v", tt.order, names, values, tt.keys, tt.values)
+		}
+	}
+}
+
+// TestOrderByValueMixed tests that members of mixed kinds keep declaration order when
+// ordered by value, and that KeysE reports the error.
+func TestOrderByValueMixed(t *testing.T) {
+	Mixed := New[struct {
+		Name  string
+		Code  int    `enum:"2"`
+		Small uint8  `enum:"1"`
+		Other string `enum:"a"`
+		Group struct {
+			A int `enum:"1"`
+		}
+		Label CodeLabel `enum:"200:OK"`
+	}]()
+	if got, want := Values[string](Mixed, WithOrder(ByValue)), []string{"Name", "a"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("Values[string](ByValue) = %v; want %v", got, want)
+	}
+
+	declared := []string{"Name", "Code", "Small", "Other", "Group", "Label"}
+	if got := Keys(Mixed, WithOrder(ByValue)); !reflect.DeepEqual(got, declared) {
+		t.Errorf("Keys(ByValue) = %v; want declaration order %v", got, declared)
+	}
+	if _, err := KeysE(Mixed, WithOrder(ByValue)); err == nil || !strings.Contains(err.Error(), "cannot order members by value") {
+		t.Errorf("KeysE(ByValue) error = %v; want mixed-kind error", err)
+	}
+	if got, want := len(Entries(Mixed, WithOrder(ByValueDesc))), 6; got != want {
+		t.Errorf("len(Entries(ByValueDesc)) = %d; want %d", got, want)
+	}
+	if got, want := AllowedNames(Mixed, ", ", WithOrder(ByValue)), "Name, Code, Small, Other, Group.A, Label"; got != want {
+		t.Errorf("AllowedNames(ByValue) = %q; want %q", got, want)
+	}
+	if keys, _ := KeysValues[any](Mixed, WithOrder(ByValue)); !reflect.DeepEqual(keys, declared) {
+		t.Errorf("KeysValues(ByValue) keys = %v; want declaration order %v", keys, declared)
+	}
+}
+
+// TestRecover tests converting New panics and other panic values into errors.
+func TestRecover(t *testing.T) {
+	type Valid struct {
+		A int `enum:"1"`
+	}
+	got, err := Recover(func() Valid { return New[Valid]() })
+	if err != nil || got.A != 1 {
+		t.Errorf("Recover(valid) = %+v, %v; want {A:1}, nil", got, err)
+	}
+
+	type Invalid struct {
+		A int8 `enum:"300"`
+	}
+	_, err = Recover(func() Invalid { return New[Invalid]() })
+	_, wantErr := TryNew[Invalid]()
+	if err == nil || err.Error() != wantErr.Error() {
+		t.Errorf("Recover(invalid) error = %v; want %v", err, wantErr)
+	}
+
+	sentinel := errors.New("sentinel")
+	if _, err := Recover(func() int { panic(sentinel) }); err != sentinel {
+		t.Errorf("Recover() error = %v; want the panicked error", err)
+	}
+}
+
+// QualifiedStatus is a named enum type whose errors are qualified by its name.
+type QualifiedStatus struct {
+	Code struct {
+		StatusOK int8 `enum:"200"`
+	}
+}
+
+// TestErrorTypeName tests that errors of named enum types start with the type's name.
+func TestErrorTypeName(t *testing.T) {
+	_, err := TryNew[QualifiedStatus]()
+	want := "type enum.QualifiedStatus field Code.StatusOK: value 200 overflows int8 range [-128, 127]"
+	if err == nil || err.Error() != want {
+		t.Errorf("TryNew() error = %v; want %q", err, want)
+	}
+
+	_, err = TryNew[QualifiedStatus](WithWrapOverflow(), WithOverrides(map[string]any{"Code.Missing": 2}))
+	if want := "type enum.QualifiedStatus unknown override paths: Code.Missing"; err == nil || err.Error() != want {
+		t.Errorf("TryNew() error = %v; want %q", err, want)
+	}
+
+	_, err = TryNew[struct {
+		A int8 `enum:"200"`
+	}]()
+	if want := "field A: value 200 overflows int8 range [-128, 127]"; err == nil || err.Error() != want {
+		t.Errorf("TryNew() of an anonymous type error = %v; want %q", err, want)
+	}
+	if err := ValidateType(reflect.TypeOf(0)); err == nil || err.Error() != "type int is not a struct" {
+		t.Errorf("ValidateType(int) error = %v; want type int is not a struct", err)
+	}
+}
+
+// SentinelStatus declares entries sentinels at the root and in a group.
+type SentinelStatus struct {
+	First int
+	All   []Entry `enum:"entries"`
+	Code  struct {
+		StatusOK       int     `enum:"200"`
+		All            []Entry `enum:"entries"`
+		StatusNotFound int     `enum:"404"`
+	}
+	Last string
+}
+
+// TestEntriesSentinel tests that entries sentinels list their siblings and are not members.
+func TestEntriesSentinel(t *testing.T) {
+	got := New[SentinelStatus]()
+
+	want := []Entry{
+		{Name: "First", Value: 0},
+		{Name: "Code.StatusOK", Value: 200, Tag: "200"},
+		{Name: "Code.StatusNotFound", Value: 404, Tag: "404"},
+		{Name: "Last", Value: "Last"},
+	}
+	if !reflect.DeepEqual(got.All, want) {
+		t.Errorf("got All %v; want %v", got.All, want)
+	}
+	wantCode := []Entry{{"StatusOK", 200, "200"}, {"StatusNotFound", 404, "404"}}
+	if !reflect.DeepEqual(got.Code.All, wantCode) {
+		t.Errorf("got Code.All %v; want %v", got.Code.All, wantCode)
+	}
+
+	// The sentinel does not shift the index of the members after it.
+	shifted := New[struct {
+		A   int
+		All []Entry `enum:"entries"`
+		B   int
+	}]()
+	if shifted.B != 1 {
+		t.Errorf("got B %d; want 1", shifted.B)
+	}
+
+	if keys := Keys(got); !reflect.DeepEqual(keys, []string{"First", "Code", "Last"}) {
+		t.Errorf("Keys() = %v; want the sentinel excluded", keys)
+	}
+	if keys := Keys(got, WithNested()); len(keys) != 4 {
+		t.Errorf("Keys(WithNested) = %v; want the four members", keys)
+	}
+	if entries := Entries(got); !reflect.DeepEqual(entries, want) {
+		t.Errorf("Entries() = %v; want %v", entries, want)
+	}
+	if _, ok := Get[[]Entry](got, "All"); ok {
+		t.Error("Get(All) found the sentinel")
+	}
+
+	_, err := TryNew[struct{ All []Entry }]()
+	if err == nil || !strings.Contains(err.Error(), `field All: entries sentinels must be tagged "entries"`) {
+		t.Errorf("TryNew() of an untagged sentinel error = %v", err)
+	}
+}
+
+// TestCountSentinel tests that count sentinels hold the number of their siblings.
+func TestCountSentinel(t *testing.T) {
+	type Color struct {
+		Red   int
+		Green int
+		Count int `enum:"count"`
+		Blue  int
+	}
+	got := New[Color]()
+	if got.Count != 3 || got.Blue != 2 {
+		t.Errorf("got %+v; want Count 3 and Blue at index 2", got)
+	}
+	if keys := Keys(got); !reflect.DeepEqual(keys, []string{"Red", "Green", "Blue"}) {
+		t.Errorf("Keys() = %v; want the sentinel excluded", keys)
+	}
+	if values := Values[int](got); !reflect.DeepEqual(values, []int{0, 1, 2}) {
+		t.Errorf("Values() = %v; want the sentinel excluded", values)
+	}
+
+	nested := New[struct {
+		Code struct {
+			StatusOK       int     `enum:"200"`
+			StatusNotFound int     `enum:"404"`
+			N              uint8   `enum:"count"`
+			All            []Entry `enum:"entries"`
+		}
+		Type struct {
+			StatusOK string
+		}
+		Name  string
+		Count int `enum:"count"`
+	}]()
+	if nested.Code.N != 2 || nested.Count != 1 {
+		t.Errorf("got Code.N %d, Count %d; want 2, 1", nested.Code.N, nested.Count)
+	}
+	if len(nested.Code.All) != 2 {
+		t.Errorf("got Code.All %v; want the two codes without the count", nested.Code.All)
+	}
+	if keys := Keys(nested, WithNested()); !reflect.DeepEqual(keys, []string{"Code.StatusOK", "Code.StatusNotFound", "Type.StatusOK", "Name"}) {
+		t.Errorf("Keys(WithNested) = %v; want the sentinels excluded", keys)
+	}
+
+	type Custom struct {
+		Code struct {
+			StatusOK int `code:"200"`
+			N        int `code:"count"`
+		}
+		Count int `code:"count"`
+	}
+	custom := New[Custom](WithTagKey("code"))
+	if custom.Code.N != 1 || custom.Count != 0 {
+		t.Errorf("got Code.N %d, Count %d; want 1, 0", custom.Code.N, custom.Count)
+	}
+	key := WithTagKey("code")
+	if keys := Keys(custom, WithNested(), key); !reflect.DeepEqual(keys, []string{"Code.StatusOK"}) {
+		t.Errorf("Keys(WithNested, WithTagKey) = %v; want the custom-key sentinels excluded", keys)
+	}
+	if entries := Entries(custom, key); len(entries) != 1 || entries[0].Name != "Code.StatusOK" {
+		t.Errorf("Entries(WithTagKey) = %v; want the custom-key sentinels excluded", entries)
+	}
+	if _, err := Parse[int](custom, "Code.N", key); err == nil {
+		t.Error("Parse(Code.N, WithTagKey) returned nil error; want the sentinel unknown")
+	}
+	if groups, leaves := Shape[Custom](key); groups != 1 || leaves != 1 {
+		t.Errorf("Shape(WithTagKey) = %d, %d; want 1, 1", groups, leaves)
+	}
+
+	// Without the key the fields are members, whatever was initialized before.
+	if keys := Keys(custom, WithNested()); !reflect.DeepEqual(keys, []string{"Code.StatusOK", "Code.N", "Count"}) {
+		t.Errorf("Keys(WithNested) = %v; want the fields listed as members", keys)
+	}
+	if groups, leaves := Shape[Custom](); groups != 1 || leaves != 3 {
+		t.Errorf("Shape() = %d, %d; want 1, 3", groups, leaves)
+	}
+}
+
+// TestIndexDefault tests that untagged and empty-tagged integers take their field index.
+func TestIndexDefault(t *testing.T) {
+	flat := New[struct {
+		A int
+		B int `enum:""`
+		C int
+	}]()
+	if flat.A != 0 || flat.B != 1 || flat.C != 2 {
+		t.Errorf("got %+v; want {A:0 B:1 C:2}", flat)
+	}
+
+	// Indices restart in each group and count fields of every type.
+	nested := New[struct {
+		Debug int
+		Name  string
+		Net   struct {
+			Timeout int
+			Refused uint8 `enum:""`
+		}
+		Fatal  int
+		Tagged int `enum:"100"`
+		Last   int
+	}]()
+	if nested.Debug != 0 || nested.Net.Timeout != 0 || nested.Net.Refused != 1 || nested.Fatal != 3 || nested.Last != 5 {
+		t.Errorf("got %+v; want Debug 0, Net {0 1}, Fatal 3, Last 5", nested)
+	}
+}
+
+// TestStrictVariants tests that KeysE, ValuesE, and ContainsE match the lenient functions
+// on structs and describe each kind of misuse.
+func TestStrictVariants(t *testing.T) {
+	status := New[struct {
+		StatusOK       int `enum:"200"`
+		StatusNotFound int `enum:"404"`
+	}]()
+
+	keys, err := KeysE(status)
+	if err != nil || !reflect.DeepEqual(keys, Keys(status)) {
+		t.Errorf("KeysE() = %v, %v; want %v, nil", keys, err, Keys(status))
+	}
+	values, err := ValuesE[int](status)
+	if err != nil || !reflect.DeepEqual(values, Values[int](status)) {
+		t.Errorf("ValuesE() = %v, %v; want %v, nil", values, err, Values[int](status))
+	}
+	if ok, err := ContainsE(status, 404); err != nil || !ok {
+		t.Errorf("ContainsE(404) = %v, %v; want true, nil", ok, err)
+	}
+
+	var nilPtr *struct{ A int }
+	tests := []struct {
+		enum any
+		want string
+	}{
+		{nil, "enum is nil, not a struct"},
+		{123, "enum is of kind int, not a struct (type int)"},
+		{[]string{"A"}, "enum is of kind slice, not a struct (type []string)"},
+		{nilPtr, "enum is a nil *struct { A int }, not a struct"},
+		{new(int), "enum is a pointer of type *int, not a struct"},
+	}
+	for _, tt := range tests {
+		if _, err := KeysE(tt.enum); err == nil || err.Error() != tt.want {
+			t.Errorf("KeysE(%T) error = %v; want %s", tt.enum, err, tt.want)
+		}
+		if _, err := ValuesE[int](tt.enum); err == nil || err.Error() != tt.want {
+			t.Errorf("ValuesE(%T) error = %v; want %s", tt.enum, err, tt.want)
+		}
+		if _, err := ContainsE(tt.enum, 200); err == nil || err.Error() != tt.want {
+			t.Errorf("ContainsE(%T) error = %v; want %s", tt.enum, err, tt.want)
+		}
+	}
+}
+
+// TestSameTag tests full and partial mirrors of sibling groups and invalid references.
+func TestSameTag(t *testing.T) {
+	got := New[struct {
+		Code struct {
+			StatusOK       int `enum:"200"`
+			StatusNotFound int `enum:"404"`
+		}
+		Mirror struct {
+			StatusOK       int64 `enum:"same=Code"`
+			StatusNotFound int64 `enum:"same=Code"`
+		}
+		Partial struct {
+			StatusOK       int `enum:"same=Code"`
+			StatusNotFound int `enum:"410"`
+		}
+		LegacyCode struct {
+			StatusOK       uint16
+			StatusNotFound uint16 `enum:"410"`
+		} `enum:"same=Code"`
+		Literal struct {
+			Value string `enum:"\\same=Code"`
+		}
+	}]()
+	if got.Mirror.StatusOK != 200 || got.Mirror.StatusNotFound != 404 {
+		t.Errorf("Mirror = %+v; want {200 404}", got.Mirror)
+	}
+	if got.Partial.StatusOK != 200 || got.Partial.StatusNotFound != 410 {
+		t.Errorf("Partial = %+v; want {200 410}", got.Partial)
+	}
+	if got.LegacyCode.StatusOK != 200 || got.LegacyCode.StatusNotFound != 410 {
+		t.Errorf("LegacyCode = %+v; want {200 410}", got.LegacyCode)
+	}
+	if got.Literal.Value != "same=Code" {
+		t.Errorf("Literal.Value = %q; want the literal tag %q", got.Literal.Value, "same=Code")
+	}
+
+	tests := []struct {
+		name string
+		try  func() error
+		want string
+	}{
+		{"missing group", func() error {
+			_, err := TryNew[struct {
+				Legacy struct {
+					A int `enum:"same=Code"`
+				}
+			}]()
+			return err
+		}, "field Legacy.A: same=Code: no group Code"},
+		{"missing member", func() error {
+			_, err := TryNew[struct {
+				Code   struct{ A int }
+				Legacy struct {
+					B int `enum:"same=Code"`
+				}
+			}]()
+			return err
+		}, "field Legacy.B: same=Code: group Code has no member B"},
+		{"declared later", func() error {
+			_, err := TryNew[struct {
+				Legacy struct {
+					A int `enum:"same=Code"`
+				}
+				Code struct{ A int }
+			}]()
+			return err
+		}, "field Legacy.A: same=Code: group Code must be declared before Legacy"},
+		{"incompatible kinds", func() error {
+			_, err := TryNew[struct {
+				Code   struct{ A string }
+				Legacy struct {
+					A int `enum:"same=Code"`
+				}
+			}]()
+			return err
+		}, "field Legacy.A: same=Code: cannot assign string to int"},
+		{"root member", func() error {
+			_, err := TryNew[struct {
+				A int `enum:"same=Code"`
+			}]()
+			return err
+		}, "field A: same=Code: member is not in a group"},
+		{"empty group", func() error {
+			_, err := TryNew[struct {
+				Legacy struct {
+					A int `enum:"same="`
+				}
+			}]()
+			return err
+		}, "field Legacy.A: invalid enum tag: same= names no group"},
+		{"default and same", func() error {
+			_, err := TryNew[struct {
+				Code   struct{ A int }
+				Legacy struct {
+					A int
+				} `enum:"default=1,same=Code"`
+			}]()
+			return err
+		}, `field Legacy: invalid group tag: options "default" and "same" are exclusive`},
+	}
+	for _, tt := range tests {
+		if err := tt.try(); err == nil || !strings.HasPrefix(err.Error(), tt.want) {
+			t.Errorf("%s: error = %v; want %s", tt.name, err, tt.want)
+		}
+	}
+}
+
+// TestPointerArguments tests that the query helpers treat a pointer to an enum like the
+// enum itself, and a nil pointer like a non-struct.
+func TestPointerArguments(t *testing.T) {
+	status := New[struct {
+		Code struct {
+			StatusOK       int `enum:"200"`
+			StatusNotFound int `enum:"404"`
+		}
+		Default string
+	}]()
+	ptr := &status
+
+	if got, want := Keys(ptr, WithNested()), Keys(status, WithNested()); !reflect.DeepEqual(got, want) {
+		t.Errorf("Keys(ptr) = %v; want %v", got, want)
+	}
+	if got, want := Values[int](ptr, WithNested()), Values[int](status, WithNested()); !reflect.DeepEqual(got, want) {
+		t.Errorf("Values(ptr) = %v; want %v", got, want)
+	}
+	if keys, values := KeysValues[string](ptr); !reflect.DeepEqual(keys, []string{"Default"}) || !reflect.DeepEqual(values, []string{"Default"}) {
+		t.Errorf("KeysValues(ptr) = %v, %v; want [Default], [Default]", keys, values)
+	}
+	if !Contains(ptr, 404, WithNested()) {
+		t.Errorf("Contains(ptr, 404) = false; want true")
+	}
+	if got, ok := Get[int](ptr, "Code.StatusNotFound"); !ok || got != 404 {
+		t.Errorf("Get(ptr, Code.StatusNotFound) = %d, %v; want 404, true", got, ok)
+	}
+	if got, want := Entries(ptr), Entries(status); !reflect.DeepEqual(got, want) {
+		t.Errorf("Entries(ptr) = %v; want %v", got, want)
+	}
+
+	var nilPtr *struct{ A int }
+	if Keys(nilPtr) != nil || Values[int](nilPtr) != nil || Contains(nilPtr, 0) || Entries(nilPtr) != nil {
+		t.Errorf("query helpers on a nil pointer returned results; want nil or false")
+	}
+	if _, ok := Get[int](nilPtr, "A"); ok {
+		t.Errorf("Get(nil pointer) ok = true; want false")
+	}
+}
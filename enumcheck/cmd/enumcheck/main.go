@@ -0,0 +1,37 @@
+// Command enumcheck reports invalid enum definitions in the packages in the given
+// directories, or the current directory, and exits with status 1 if it finds any.
+//
+// Usage:
+//
+//	enumcheck [dir ...]
+package main
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/tnnmigga/enum/enumcheck"
+)
+
+func main() {
+	dirs := os.Args[1:]
+	if len(dirs) == 0 {
+		dirs = []string{"."}
+	}
+
+	failed := false
+	for _, dir := range dirs {
+		diags, err := enumcheck.CheckDir(dir)
+		if err != nil {
+			fmt.Fprintln(os.Stderr, "enumcheck:", err)
+			os.Exit(2)
+		}
+		for _, d := range diags {
+			fmt.Println(d)
+			failed = true
+		}
+	}
+	if failed {
+		os.Exit(1)
+	}
+}
@@ -0,0 +1,415 @@
+// Package enumcheck checks the struct types passed to the constructors of package enum,
+// such as enum.New[T], without running them, so that invalid tags fail CI instead of
+// panicking at startup. It applies the tag grammar and integer range rules of the
+// runtime, shared through an internal package, and reports diagnostics positioned at
+// the offending struct field:
+//
+//   - integer tags that do not parse,
+//   - integer tags that overflow the field's width,
+//   - malformed tag options, such as a non-numeric weight,
+//   - invalid marker and group tags, such as an unknown option,
+//   - untagged, malformed, or inverted ranges, and malformed or overflowing code labels,
+//   - pointer fields other than *regexp.Regexp, and invalid or untagged patterns,
+//   - fields of unsupported kinds, such as floats, maps, or slices.
+//
+// The check is static: it reads tags under the default "enum" key and ignores what only
+// exists at run time, namely options passed to the constructor, the values of group
+// defaults and offsets, "@name:Member" references, "same=" mirrors, and template tags.
+//
+// The package depends on the standard library only. Check has the shape of an analysis
+// pass, so it can be wrapped in a golang.org/x/tools/go/analysis Analyzer, while
+// CheckDir and the enumcheck command run it directly.
+package enumcheck
+
+import (
+	"fmt"
+	"go/ast"
+	"go/importer"
+	"go/parser"
+	"go/token"
+	"go/types"
+	"os"
+	"path/filepath"
+	"reflect"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/tnnmigga/enum/internal/tagrule"
+)
+
+// enumPath is the import path of package enum.
+const enumPath = "github.com/tnnmigga/enum"
+
+// constructors are the functions of package enum whose type argument is checked.
+var constructors = map[string]bool{
+	"New":               true,
+	"TryNew":            true,
+	"NewWith":           true,
+	"NewUnchecked":      true,
+	"NewGroups":         true,
+	"NewFromTemplate":   true,
+	"NewFromJSON":       true,
+	"NewWithTranslator": true,
+	"Compile":           true,
+	"Of":                true,
+}
+
+// Diagnostic is a problem found in an enum type.
+type Diagnostic struct {
+	Pos     token.Pos
+	Message string
+}
+
+// Check returns the diagnostics for the type arguments of the enum constructors called
+// in files, whose types must be recorded in info.Types. Each type is checked once,
+// however often it is constructed.
+func Check(files []*ast.File, info *types.Info) []Diagnostic {
+	var diags []Diagnostic
+	checked := make(map[types.Type]bool)
+	for _, file := range files {
+		names := enumImportNames(file)
+		if len(names) == 0 {
+			continue
+		}
+		ast.Inspect(file, func(n ast.Node) bool {
+			index, ok := n.(*ast.IndexExpr)
+			if !ok {
+				return true
+			}
+			sel, ok := index.X.(*ast.SelectorExpr)
+			if !ok || !constructors[sel.Sel.Name] {
+				return true
+			}
+			if pkg, ok := sel.X.(*ast.Ident); !ok || !names[pkg.Name] {
+				return true
+			}
+
+			typ := info.TypeOf(index.Index)
+			if typ == nil || checked[typ] {
+				return true
+			}
+			checked[typ] = true
+			st, ok := typ.Underlying().(*types.Struct)
+			if !ok {
+				diags = append(diags, Diagnostic{index.Index.Pos(), fmt.Sprintf("type %s is not a struct", typ)})
+				return true
+			}
+			checkStruct(st, "", false, &diags)
+			return true
+		})
+	}
+	return diags
+}
+
+// enumImportNames returns the names under which file imports package enum.
+func enumImportNames(file *ast.File) map[string]bool {
+	names := make(map[string]bool)
+	for _, spec := range file.Imports {
+		if path, _ := strconv.Unquote(spec.Path.Value); path != enumPath {
+			continue
+		}
+		if spec.Name != nil {
+			names[spec.Name.Name] = true
+		} else {
+			names["enum"] = true
+		}
+	}
+	return names
+}
+
+// checkStruct appends the diagnostics for the fields of st, a group at path. filled
+// reports whether an enclosing group tag gives untagged members a default or mirror.
+func checkStruct(st *types.Struct, path string, filled bool, diags *[]Diagnostic) {
+	for i := 0; i < st.NumFields(); i++ {
+		field := st.Field(i)
+		fieldPath := field.Name()
+		if path != "" {
+			fieldPath = path + "." + fieldPath
+		}
+		report := func(format string, args ...any) {
+			*diags = append(*diags, Diagnostic{field.Pos(), "field " + fieldPath + ": " + fmt.Sprintf(format, args...)})
+		}
+
+		tag, tagged := reflect.StructTag(st.Tag(i)).Lookup("enum")
+		if field.Name() == "_" {
+			if tagged {
+				if _, err := tagrule.ParseMarker(tag); err != nil {
+					report("invalid marker: %v", err)
+				}
+			}
+			continue
+		}
+		if !field.Exported() || strings.Contains(tag, "{{") {
+			continue
+		}
+		typ := field.Type()
+		if isEntries(typ) {
+			continue
+		}
+
+		// Check the options of nested groups, which do not apply to their members' tags.
+		if under, ok := typ.Underlying().(*types.Struct); ok && !isCodeLabel(under) && !isEnumType(typ, "Range") {
+			group, err := tagrule.ParseGroup(tag)
+			if err != nil {
+				report("invalid group tag: %v", err)
+			}
+			checkStruct(under, fieldPath, filled || group.HasDefault || group.Same != "", diags)
+			continue
+		}
+
+		value, _, err := tagrule.Parse(tag)
+		if group, mirror := tagrule.Mirror(value); mirror {
+			if group == "" {
+				report("invalid enum tag: same= names no group")
+			}
+			continue
+		}
+		if tagrule.IsReference(value) {
+			continue
+		}
+
+		// Ranges must be tagged, unless a group default fills them.
+		if isEnumType(typ, "Range") {
+			if err != nil {
+				report("invalid enum tag: %v", err)
+			} else if value = tagrule.Literal(value); value == "" {
+				if !filled {
+					report("range members must be tagged")
+				}
+			} else if _, _, err := tagrule.ParseRange(value); err != nil {
+				report("invalid enum tag: %v", err)
+			}
+			continue
+		}
+
+		// Patterns are the only pointer members.
+		if ptr, ok := typ.Underlying().(*types.Pointer); ok {
+			if !isRegexp(ptr.Elem()) {
+				report("pointer types are not supported")
+				continue
+			}
+			if tag == "" {
+				report("pattern members must be tagged")
+			} else if _, err := regexp.Compile(tag); err != nil {
+				report("invalid pattern: %v", err)
+			}
+			continue
+		}
+
+		switch under := typ.Underlying().(type) {
+		case *types.Struct:
+			checkCodeLabel(under, tag, report)
+		case *types.Basic:
+			checkBasic(under, tag, report)
+		default:
+			if under != types.Typ[types.Invalid] {
+				report("unsupported type %s; only string, integer, or struct types are allowed", kindName(under))
+			}
+		}
+	}
+}
+
+// checkCodeLabel reports the problems of a "code:label" tag on a code-label member st.
+func checkCodeLabel(st *types.Struct, tag string, report func(format string, args ...any)) {
+	value, _, err := tagrule.Parse(tag)
+	if err != nil {
+		report("invalid enum tag: %v", err)
+		return
+	}
+	if value = tagrule.Literal(value); value == "" {
+		return
+	}
+	n, _, err := tagrule.ParseCodeLabel(value)
+	if err != nil {
+		report("invalid enum tag: %v", err)
+		return
+	}
+	for i := 0; i < st.NumFields(); i++ {
+		if code := st.Field(i); code.Name() == "Code" {
+			checkCode(code.Type(), n, report)
+		}
+	}
+}
+
+// checkCode reports whether the code n overflows typ, the type of a Code field.
+func checkCode(typ types.Type, n int64, report func(format string, args ...any)) {
+	kind := basicKinds[typ.Underlying().(*types.Basic).Kind()]
+	switch kind {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		if err := tagrule.CheckInt(n, kind); err != nil {
+			report("code: %v", err)
+		}
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		if n < 0 {
+			report("code: cannot assign int64 to %s: value %d is negative", typ, n)
+		} else if err := tagrule.CheckUint(uint64(n), kind); err != nil {
+			report("code: %v", err)
+		}
+	}
+}
+
+// checkBasic reports the problems of a tag on a member of the basic type typ.
+func checkBasic(typ *types.Basic, tag string, report func(format string, args ...any)) {
+	kind, ok := basicKinds[typ.Kind()]
+	if !ok {
+		if typ.Kind() != types.Invalid {
+			report("unsupported type %s; only string, integer, or struct types are allowed", typ.Name())
+		}
+		return
+	}
+	if tag == "count" && kind != reflect.String {
+		return
+	}
+	value, _, err := tagrule.Parse(tag)
+	if err != nil {
+		report("invalid enum tag: %v", err)
+		return
+	}
+	value = tagrule.Literal(value)
+	if value == "" {
+		return
+	}
+
+	switch kind {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		n, err := tagrule.ParseInt(value)
+		if err != nil {
+			report("invalid enum tag: %v", err)
+		} else if err := tagrule.CheckInt(n, kind); err != nil {
+			report("%v", err)
+		}
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		n, err := tagrule.ParseUint(value)
+		if err != nil {
+			report("invalid enum tag: %v", err)
+		} else if err := tagrule.CheckUint(n, kind); err != nil {
+			report("%v", err)
+		}
+	}
+}
+
+// basicKinds maps the basic types supported as members to their reflect kinds.
+var basicKinds = map[types.BasicKind]reflect.Kind{
+	types.String: reflect.String,
+	types.Int:    reflect.Int,
+	types.Int8:   reflect.Int8,
+	types.Int16:  reflect.Int16,
+	types.Int32:  reflect.Int32,
+	types.Int64:  reflect.Int64,
+	types.Uint:   reflect.Uint,
+	types.Uint8:  reflect.Uint8,
+	types.Uint16: reflect.Uint16,
+	types.Uint32: reflect.Uint32,
+	types.Uint64: reflect.Uint64,
+}
+
+// kindName returns the name of the reflect kind of the composite type typ.
+func kindName(typ types.Type) string {
+	switch typ.(type) {
+	case *types.Slice:
+		return "slice"
+	case *types.Array:
+		return "array"
+	case *types.Map:
+		return "map"
+	case *types.Chan:
+		return "chan"
+	case *types.Signature:
+		return "func"
+	case *types.Interface:
+		return "interface"
+	}
+	return typ.String()
+}
+
+// isCodeLabel reports whether st has exactly an integer Code and a string Label field.
+func isCodeLabel(st *types.Struct) bool {
+	if st.NumFields() != 2 {
+		return false
+	}
+	fields := map[string]types.Type{}
+	for i := 0; i < st.NumFields(); i++ {
+		fields[st.Field(i).Name()] = st.Field(i).Type().Underlying()
+	}
+	code, ok := fields["Code"].(*types.Basic)
+	if !ok || code.Info()&types.IsInteger == 0 {
+		return false
+	}
+	label, ok := fields["Label"].(*types.Basic)
+	return ok && label.Kind() == types.String
+}
+
+// isEnumType reports whether typ is the type of package enum with the given name.
+func isEnumType(typ types.Type, name string) bool {
+	return isNamed(typ, enumPath, name)
+}
+
+// isEntries reports whether typ is []enum.Entry, the type of entries sentinels.
+func isEntries(typ types.Type) bool {
+	slice, ok := typ.(*types.Slice)
+	return ok && isEnumType(slice.Elem(), "Entry")
+}
+
+// isRegexp reports whether typ is regexp.Regexp.
+func isRegexp(typ types.Type) bool {
+	return isNamed(typ, "regexp", "Regexp")
+}
+
+// isNamed reports whether typ is the named type pkgPath.name.
+func isNamed(typ types.Type, pkgPath, name string) bool {
+	named, ok := typ.(*types.Named)
+	if !ok {
+		return false
+	}
+	obj := named.Obj()
+	return obj.Pkg() != nil && obj.Pkg().Path() == pkgPath && obj.Name() == name
+}
+
+// CheckDir parses and type-checks the packages in dir, test files included, and
+// returns their diagnostics formatted as "file:line:column: message", in order.
+// Errors in type-checking the packages themselves are ignored; fields whose types
+// cannot be resolved are skipped. Returns an error if dir cannot be read or parsed.
+func CheckDir(dir string) ([]string, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	// Parse the files, grouped by package name.
+	fset := token.NewFileSet()
+	packages := make(map[string][]*ast.File)
+	var names []string
+	for _, entry := range entries {
+		if entry.IsDir() || !strings.HasSuffix(entry.Name(), ".go") {
+			continue
+		}
+		file, err := parser.ParseFile(fset, filepath.Join(dir, entry.Name()), nil, parser.SkipObjectResolution)
+		if err != nil {
+			return nil, err
+		}
+		if _, ok := packages[file.Name.Name]; !ok {
+			names = append(names, file.Name.Name)
+		}
+		packages[file.Name.Name] = append(packages[file.Name.Name], file)
+	}
+
+	var diags []Diagnostic
+	for _, name := range names {
+		conf := types.Config{
+			Importer: importer.ForCompiler(fset, "source", nil),
+			Error:    func(error) {},
+		}
+		info := &types.Info{Types: make(map[ast.Expr]types.TypeAndValue)}
+		_, _ = conf.Check(name, fset, packages[name], info)
+		diags = append(diags, Check(packages[name], info)...)
+	}
+
+	sort.SliceStable(diags, func(i, j int) bool { return diags[i].Pos < diags[j].Pos })
+	lines := make([]string, len(diags))
+	for i, d := range diags {
+		lines[i] = fmt.Sprintf("%s: %s", fset.Position(d.Pos), d.Message)
+	}
+	return lines, nil
+}
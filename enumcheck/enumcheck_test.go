@@ -0,0 +1,64 @@
+package enumcheck
+
+import (
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+// TestCheckDir tests each diagnostic against the "// want `regexp`" comments of the
+// testdata packages, in the manner of analysistest: every diagnostic must match a want
+// on its line, and every want must be matched.
+func TestCheckDir(t *testing.T) {
+	dir := filepath.Join("testdata", "src", "a")
+	got, err := CheckDir(dir)
+	if err != nil {
+		t.Fatalf("CheckDir() error = %v", err)
+	}
+
+	wants := expectations(t, filepath.Join(dir, "a.go"))
+	for _, line := range got {
+		// Diagnostics are formatted as "file:line:column: message".
+		parts := strings.SplitN(line, ":", 4)
+		lineNo, _ := strconv.Atoi(parts[1])
+		message := strings.TrimPrefix(parts[3], " ")
+		want, ok := wants[lineNo]
+		if !ok {
+			t.Errorf("unexpected diagnostic %s", line)
+			continue
+		}
+		if !want.MatchString(message) {
+			t.Errorf("diagnostic %s does not match %s", line, want)
+		}
+		delete(wants, lineNo)
+	}
+	for lineNo, want := range wants {
+		t.Errorf("line %d: no diagnostic matching %s", lineNo, want)
+	}
+}
+
+// expectations returns the "// want `regexp`" comments of the file by line.
+func expectations(t *testing.T, path string) map[int]*regexp.Regexp {
+	t.Helper()
+	src, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	wants := make(map[int]*regexp.Regexp)
+	for i, line := range strings.Split(string(src), "\n") {
+		if _, want, ok := strings.Cut(line, "// want `"); ok {
+			wants[i+1] = regexp.MustCompile(strings.TrimSuffix(want, "`"))
+		}
+	}
+	return wants
+}
+
+// TestCheckDirMissing tests that an unreadable directory is an error.
+func TestCheckDirMissing(t *testing.T) {
+	if _, err := CheckDir(filepath.Join("testdata", "missing")); err == nil {
+		t.Error("CheckDir() of a missing directory returned nil error")
+	}
+}
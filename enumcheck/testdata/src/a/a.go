@@ -0,0 +1,95 @@
+package a
+
+import (
+	"regexp"
+
+	"github.com/tnnmigga/enum"
+)
+
+type Status struct {
+	Code struct {
+		StatusOK int8   `enum:"200"`   // want `^field Code.StatusOK: value 200 overflows int8 range \[-128, 127\]$`
+		Bad      int    `enum:"abc"`   // want `^field Code.Bad: invalid enum tag: strconv.ParseInt: parsing "abc": invalid syntax$`
+		Negative uint8  `enum:"-1"`    // want `^field Code.Negative: invalid enum tag: strconv.ParseUint`
+		Large    uint16 `enum:"65536"` // want `^field Code.Large: value 65536 overflows uint16 range`
+		Fine     int16  `enum:"-300"`
+	}
+	Pointer  *string        // want `^field Pointer: pointer types are not supported$`
+	Ratio    float64        // want `^field Ratio: unsupported type float64; only string, integer, or struct types are allowed$`
+	Names    []string       // want `^field Names: unsupported type slice;`
+	Route    *regexp.Regexp `enum:"^(a$"` // want `^field Route: invalid pattern: error parsing regexp`
+	Untagged *regexp.Regexp // want `^field Untagged: pattern members must be tagged$`
+	Weighted string         `enum:"A,weight=heavy"` // want `^field Weighted: invalid enum tag: invalid option "weight=heavy"`
+	Valid    *regexp.Regexp `enum:"^a+$"`
+	Label    enum.CodeLabel `enum:"404:Not Found"`
+	Class    enum.Range     `enum:"200-299"`
+	All      []enum.Entry   `enum:"entries"`
+	Count    int            `enum:"count"`
+	Ref      int8           `enum:"@other:Member"`
+	NotRef   int8           `enum:"@other"` // want `^field NotRef: invalid enum tag: strconv.ParseInt: parsing "@other": invalid syntax$`
+	Literal  string         `enum:"\\@user:name"`
+	Same     int8           `enum:"same=Code"`
+	NoGroup  int8           `enum:"same="` // want `^field NoGroup: invalid enum tag: same= names no group$`
+	Escaped  string         `enum:"\\same=Code"`
+	Template int8           `enum:"{{.Code}}"`
+	hidden   float64
+}
+
+// Grouped has marker, group, range, and code-label tags, which are parsed like the
+// runtime parses them.
+type Grouped struct {
+	_      struct{} `enum:"start=1,case=snake"`
+	Marked struct {
+		_ struct{} `enum:"step=x"` // want `^field Marked._: invalid marker: invalid option "step=x"`
+		A int
+	}
+	Unknown struct { // want `^field Unknown: invalid group tag: unknown option "size"$`
+		A int
+	} `enum:"size=2"`
+	Tagged struct { // want `^field Tagged: invalid group tag: option "tagged" requires a prefix$`
+		A string
+	} `enum:"tagged"`
+	Named struct {
+		A int
+	} `enum:"group1"`
+	Defaulted struct {
+		Span enum.Range
+	} `enum:"default=1-2"`
+	Untagged enum.Range     // want `^field Untagged: range members must be tagged$`
+	Inverted enum.Range     `enum:"5-1"`       // want `^field Inverted: invalid enum tag: range "5-1" is inverted: 5 > 1$`
+	Bounds   enum.Range     `enum:"1"`         // want `^field Bounds: invalid enum tag: "1" is not of the form lo-hi$`
+	NoLabel  enum.CodeLabel `enum:"404"`       // want `^field NoLabel: invalid enum tag: "404" is not of the form code:label$`
+	BadCode  enum.CodeLabel `enum:"x:Label"`   // want `^field BadCode: invalid enum tag: strconv.ParseInt: parsing "x"`
+	Small    SmallLabel     `enum:"300:Large"` // want `^field Small: code: value 300 overflows uint8 \(byte\) range \[0, 255\]$`
+	Signed   SmallLabel     `enum:"-1:Signed"` // want `^field Signed: code: cannot assign int64 to uint8: value -1 is negative$`
+}
+
+// SmallLabel is a code-label type with a narrow code.
+type SmallLabel struct {
+	Code  uint8
+	Label string
+}
+
+// Unchecked is never passed to a constructor.
+type Unchecked struct {
+	Ratio float64
+}
+
+var (
+	S     = enum.New[Status]()
+	Again = enum.New[Status]()
+	_     = enum.New[int]() // want `^type int is not a struct$`
+	_, _  = enum.TryNew[struct {
+		Small int8 `enum:"-129"` // want `^field Small: value -129 overflows int8`
+	}]()
+	G    = enum.NewWithTranslator[Grouped](func(name string) string { return name })
+	_, _ = enum.NewGroups[struct {
+		Groups int8 `enum:"128"` // want `^field Groups: value 128 overflows int8`
+	}]()
+	_, _ = enum.NewFromJSON[struct {
+		JSON int8 `enum:"128"` // want `^field JSON: value 128 overflows int8`
+	}](nil)
+	_ = enum.Compile[struct {
+		Compiled int8 `enum:"128"` // want `^field Compiled: value 128 overflows int8`
+	}]()
+)
@@ -0,0 +1,121 @@
+// Package enumdoc reads member descriptions from the Go comments of enum declarations,
+// so that they need not be repeated in desc= tag options.
+//
+// Like package enumscan, it works on syntax alone with go/parser, keeping package enum
+// free of dependencies outside the standard library.
+package enumdoc
+
+import (
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"io/fs"
+	"strings"
+)
+
+// DocDescriptions parses the non-test Go files in pkgDir and returns the comments of the
+// fields of the enum typeName, keyed by dotted member path such as "Code.StatusOK", for
+// use with enum.WithDescriptions. typeName names either a struct type, or a variable
+// initialized with an enum constructor over an anonymous struct, as in
+// var Status = enum.New[struct{ ... }](). A field's doc comment is preferred over its
+// line comment, and the text is joined into a single line. Fields of nested groups,
+// anonymous or struct types declared in the same package, are keyed by their dotted
+// path; the groups themselves are keyed too if commented. Uncommented fields are
+// omitted. Returns an error if the files cannot be parsed or typeName is not found.
+func DocDescriptions(pkgDir, typeName string) (map[string]string, error) {
+	fset := token.NewFileSet()
+	notTest := func(info fs.FileInfo) bool { return !strings.HasSuffix(info.Name(), "_test.go") }
+	pkgs, err := parser.ParseDir(fset, pkgDir, notTest, parser.ParseComments|parser.SkipObjectResolution)
+	if err != nil {
+		return nil, err
+	}
+
+	// Collect the struct types and the enum variables declared in the package.
+	types := make(map[string]*ast.StructType)
+	vars := make(map[string]*ast.StructType)
+	for _, pkg := range pkgs {
+		for _, file := range pkg.Files {
+			for _, decl := range file.Decls {
+				gen, ok := decl.(*ast.GenDecl)
+				if !ok {
+					continue
+				}
+				for _, spec := range gen.Specs {
+					switch spec := spec.(type) {
+					case *ast.TypeSpec:
+						if st, ok := spec.Type.(*ast.StructType); ok {
+							types[spec.Name.Name] = st
+						}
+					case *ast.ValueSpec:
+						for i, name := range spec.Names {
+							if i < len(spec.Values) {
+								if st := typeArgument(spec.Values[i]); st != nil {
+									vars[name.Name] = st
+								}
+							}
+						}
+					}
+				}
+			}
+		}
+	}
+
+	st, ok := types[typeName]
+	if !ok {
+		st, ok = vars[typeName]
+	}
+	if !ok {
+		return nil, fmt.Errorf("no struct type or enum variable %s in %s", typeName, pkgDir)
+	}
+	descriptions := make(map[string]string)
+	collect(descriptions, types, st, "", map[*ast.StructType]bool{})
+	return descriptions, nil
+}
+
+// typeArgument returns the anonymous struct type argument of a generic call such as
+// enum.New[struct{ ... }](), or nil.
+func typeArgument(expr ast.Expr) *ast.StructType {
+	call, ok := expr.(*ast.CallExpr)
+	if !ok {
+		return nil
+	}
+	index, ok := call.Fun.(*ast.IndexExpr)
+	if !ok {
+		return nil
+	}
+	st, _ := index.Index.(*ast.StructType)
+	return st
+}
+
+// collect adds the descriptions of the fields of st, prefixed by prefix, descending into
+// groups. visiting guards against recursive named types.
+func collect(descriptions map[string]string, types map[string]*ast.StructType, st *ast.StructType, prefix string, visiting map[*ast.StructType]bool) {
+	visiting[st] = true
+	defer delete(visiting, st)
+
+	for _, field := range st.Fields.List {
+		text := field.Doc.Text()
+		if text == "" {
+			text = field.Comment.Text()
+		}
+		text = strings.Join(strings.Fields(text), " ")
+
+		group, _ := field.Type.(*ast.StructType)
+		if ident, ok := field.Type.(*ast.Ident); ok {
+			group = types[ident.Name]
+		}
+		for _, name := range field.Names {
+			if !name.IsExported() {
+				continue
+			}
+			path := prefix + name.Name
+			if text != "" {
+				descriptions[path] = text
+			}
+			if group != nil && !visiting[group] {
+				collect(descriptions, types, group, path+".", visiting)
+			}
+		}
+	}
+}
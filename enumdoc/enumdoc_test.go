@@ -0,0 +1,38 @@
+package enumdoc
+
+import (
+	"reflect"
+	"testing"
+)
+
+// TestDocDescriptions tests doc, line, and missing comments, nested and named groups.
+func TestDocDescriptions(t *testing.T) {
+	got, err := DocDescriptions("testdata/status", "Status")
+	if err != nil {
+		t.Fatalf("DocDescriptions() error = %v", err)
+	}
+	want := map[string]string{
+		"Code":                "Code holds the numeric status codes.",
+		"Code.StatusOK":       "StatusOK means the request succeeded.",
+		"Code.StatusNotFound": "StatusNotFound means there is no such resource.",
+		"Reason.Timeout":      "Timeout means the upstream did not answer in time.",
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("DocDescriptions(Status) = %v; want %v", got, want)
+	}
+
+	got, err = DocDescriptions("testdata/status", "Reason")
+	if err != nil || !reflect.DeepEqual(got, map[string]string{"Timeout": "Timeout means the upstream did not answer in time."}) {
+		t.Errorf("DocDescriptions(Reason) = %v, %v", got, err)
+	}
+}
+
+// TestDocDescriptionsErrors tests unknown names and unreadable directories.
+func TestDocDescriptionsErrors(t *testing.T) {
+	if _, err := DocDescriptions("testdata/status", "Missing"); err == nil {
+		t.Errorf("DocDescriptions(Missing) error = nil; want an error")
+	}
+	if _, err := DocDescriptions("testdata/none", "Status"); err == nil {
+		t.Errorf("DocDescriptions(testdata/none) error = nil; want an error")
+	}
+}
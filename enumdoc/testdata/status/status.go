@@ -0,0 +1,24 @@
+package status
+
+import "github.com/tnnmigga/enum"
+
+// Status is an enum declared with an anonymous struct.
+var Status = enum.New[struct {
+	// Code holds the numeric status codes.
+	Code struct {
+		// StatusOK means the request
+		// succeeded.
+		StatusOK       int `enum:"200"`
+		StatusNotFound int `enum:"404"` // StatusNotFound means there is no such resource.
+		StatusTeapot   int `enum:"418"`
+	}
+	Reason Reason
+	Legacy string
+}]()
+
+// Reason is a named group of Status.
+type Reason struct {
+	Timeout string // Timeout means the upstream did not answer in time.
+	Denied  string
+	hidden  string // hidden is unexported.
+}
@@ -0,0 +1,158 @@
+// Package enumscan finds enum members that no source code refers to.
+//
+// It works on syntax alone, with go/parser rather than go/packages, so that package
+// enum keeps no dependencies outside the standard library. References are therefore
+// matched by name: a selector chain such as Status.Code.StatusOK counts as a use of
+// the member Code.StatusOK when it is rooted at an identifier named like the enum
+// variable, or at such an identifier qualified by an imported package name, as in
+// status.Status.Code.StatusOK. A local variable shadowing the enum variable can hide
+// unused members, and members reached only through reflection, such as by enum.Keys
+// or enum.Parse, are reported as unused.
+package enumscan
+
+import (
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"io/fs"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/tnnmigga/enum"
+)
+
+// FindUnused returns the leaf members of e, by dotted name in declaration order, that
+// are never selected from the variable enumVarName in the Go files matched by
+// pkgPattern. pkgPattern is a directory, or a directory followed by "/..." to include
+// its subdirectories like a go list pattern; as with the go tool, directories named
+// testdata or vendor, or starting with "." or "_", are skipped below it. Test files
+// are included. Selecting a nested group, as in Status.Code, uses all of its members.
+// Returns an error if e is not a struct or a file cannot be read or parsed.
+func FindUnused(pkgPattern string, e any, enumVarName string) ([]string, error) {
+	members := enum.Keys(e, enum.WithNested())
+	if members == nil {
+		return nil, fmt.Errorf("type %T is not a struct or has no members", e)
+	}
+
+	files, err := goFiles(pkgPattern)
+	if err != nil {
+		return nil, err
+	}
+	used := make(map[string]bool)
+	fset := token.NewFileSet()
+	for _, path := range files {
+		file, err := parser.ParseFile(fset, path, nil, parser.SkipObjectResolution)
+		if err != nil {
+			return nil, err
+		}
+		for _, sel := range selections(file, enumVarName) {
+			markUsed(used, members, sel)
+		}
+	}
+
+	var unused []string
+	for _, name := range members {
+		if !used[name] {
+			unused = append(unused, name)
+		}
+	}
+	return unused, nil
+}
+
+// goFiles returns the Go files matched by pkgPattern.
+func goFiles(pkgPattern string) ([]string, error) {
+	root, recursive := strings.TrimSuffix(pkgPattern, "/..."), strings.HasSuffix(pkgPattern, "/...")
+	if root == "" {
+		root = "."
+	}
+
+	var files []string
+	err := filepath.WalkDir(root, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() {
+			if path == root {
+				return nil
+			}
+			name := d.Name()
+			if !recursive || name == "testdata" || name == "vendor" || strings.HasPrefix(name, ".") || strings.HasPrefix(name, "_") {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if strings.HasSuffix(path, ".go") {
+			files = append(files, path)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return files, nil
+}
+
+// selections returns the dotted paths selected from the variable varName in file, such
+// as "Code.StatusOK" for Status.Code.StatusOK. Only maximal selector chains are
+// considered, so each reference yields its full path once.
+func selections(file *ast.File, varName string) []string {
+	imports := make(map[string]bool)
+	for _, spec := range file.Imports {
+		importPath, _ := strconv.Unquote(spec.Path.Value)
+		name := importPath[strings.LastIndex(importPath, "/")+1:]
+		if spec.Name != nil {
+			name = spec.Name.Name
+		}
+		imports[name] = true
+	}
+
+	var paths []string
+	ast.Inspect(file, func(n ast.Node) bool {
+		sel, ok := n.(*ast.SelectorExpr)
+		if !ok {
+			return true
+		}
+
+		// Flatten the chain, which must be rooted at an identifier.
+		var names []string
+		var x ast.Expr = sel
+		for {
+			s, ok := x.(*ast.SelectorExpr)
+			if !ok {
+				break
+			}
+			names = append([]string{s.Sel.Name}, names...)
+			x = s.X
+		}
+		root, ok := x.(*ast.Ident)
+		if !ok {
+			return true
+		}
+		names = append([]string{root.Name}, names...)
+
+		switch {
+		case names[0] == varName:
+			paths = append(paths, strings.Join(names[1:], "."))
+		case imports[names[0]] && len(names) > 2 && names[1] == varName:
+			paths = append(paths, strings.Join(names[2:], "."))
+		}
+		return false
+	})
+	return paths
+}
+
+// markUsed marks the members selected by path: the member it names or extends into,
+// such as Label for Label.Code on a code-label member, or every member of the group
+// it names.
+func markUsed(used map[string]bool, members []string, path string) {
+	if path == "" {
+		return
+	}
+	for _, name := range members {
+		if name == path || strings.HasPrefix(path, name+".") || strings.HasPrefix(name, path+".") {
+			used[name] = true
+		}
+	}
+}
@@ -0,0 +1,59 @@
+package enumscan
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/tnnmigga/enum"
+)
+
+// Status is the enum whose uses are scanned in testdata/app.
+var Status = enum.New[struct {
+	Code struct {
+		StatusOK       int `enum:"200"`
+		StatusNotFound int `enum:"404"`
+		StatusTeapot   int `enum:"418"`
+	}
+	Type struct {
+		StatusOK      string
+		StatusUnknown string
+	}
+	Reason struct {
+		Timeout string
+		Denied  string
+	}
+	Label  enum.CodeLabel `enum:"500:Internal"`
+	Legacy string
+}]()
+
+// TestFindUnused tests finding unused members across a package tree.
+func TestFindUnused(t *testing.T) {
+	got, err := FindUnused("testdata/app/...", Status, "Status")
+	if err != nil {
+		t.Fatalf("FindUnused() error = %v", err)
+	}
+	want := []string{"Code.StatusTeapot", "Type.StatusUnknown", "Legacy"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("FindUnused() = %v; want %v", got, want)
+	}
+
+	// Without "/...", only the root package is scanned.
+	got, err = FindUnused("testdata/app", Status, "Status")
+	if err != nil {
+		t.Fatalf("FindUnused() error = %v", err)
+	}
+	want = []string{"Code.StatusNotFound", "Code.StatusTeapot", "Type.StatusOK", "Type.StatusUnknown", "Reason.Timeout", "Reason.Denied", "Label", "Legacy"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("FindUnused(non-recursive) = %v; want %v", got, want)
+	}
+}
+
+// TestFindUnusedErrors tests invalid enums and missing directories.
+func TestFindUnusedErrors(t *testing.T) {
+	if _, err := FindUnused("testdata/app", 42, "Status"); err == nil {
+		t.Error("FindUnused(42) returned nil error")
+	}
+	if _, err := FindUnused("testdata/missing/...", Status, "Status"); err == nil {
+		t.Error("FindUnused() of a missing directory returned nil error")
+	}
+}
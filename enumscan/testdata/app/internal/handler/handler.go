@@ -0,0 +1,17 @@
+package handler
+
+import (
+	"fmt"
+
+	app "example.com/app"
+)
+
+// Handle prints the status type and reason for a code.
+func Handle(code int) {
+	switch code {
+	case app.Status.Code.StatusNotFound:
+		fmt.Println(app.Status.Type.StatusOK)
+	}
+	reasons := app.Status.Reason
+	fmt.Println(reasons, app.Status.Label.Code)
+}
@@ -0,0 +1,28 @@
+package app
+
+// Status mirrors the enum declared by the enumscan tests.
+var Status struct {
+	Code struct {
+		StatusOK       int
+		StatusNotFound int
+		StatusTeapot   int
+	}
+	Type struct {
+		StatusOK      string
+		StatusUnknown string
+	}
+	Reason struct {
+		Timeout string
+		Denied  string
+	}
+	Label struct {
+		Code  int
+		Label string
+	}
+	Legacy string
+}
+
+// OK reports whether code is the OK status.
+func OK(code int) bool {
+	return code == Status.Code.StatusOK
+}
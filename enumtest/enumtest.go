@@ -0,0 +1,81 @@
+// Package enumtest provides test helpers for enums initialized by package enum.
+package enumtest
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/tnnmigga/enum"
+)
+
+// AssertBijective checks that the leaf members of e map one-to-one between names and
+// values, so that reverse lookups such as Enum.NameOf are safe: every value belongs to
+// exactly one member, and parsing each name and mapping its value back yields the same
+// name. It reports each violation through t.Errorf, listing every value shared by
+// several members. Members of different types never collide. Members whose values cannot
+// be compared are reported, and the round trip is then skipped. Like the query functions
+// of package enum, it accepts a non-nil pointer to the enum as well.
+func AssertBijective(t testing.TB, e any) {
+	t.Helper()
+	val := reflect.ValueOf(e)
+	if val.Kind() == reflect.Ptr && !val.IsNil() {
+		val = val.Elem()
+	}
+	if val.Kind() != reflect.Struct {
+		t.Errorf("type %T is not a struct", e)
+		return
+	}
+
+	// Group names by value, keeping the first-seen order of values.
+	var order []any
+	names := make(map[any][]string)
+	uncomparable := false
+	for _, entry := range enum.Entries(e) {
+		if !reflect.TypeOf(entry.Value).Comparable() {
+			t.Errorf("member %s: values of type %T cannot be compared", entry.Name, entry.Value)
+			uncomparable = true
+			continue
+		}
+		if _, ok := names[entry.Value]; !ok {
+			order = append(order, entry.Value)
+		}
+		names[entry.Value] = append(names[entry.Value], entry.Name)
+	}
+	for _, value := range order {
+		if members := names[value]; len(members) > 1 {
+			t.Errorf("value %s is shared by %s", formatValue(value), strings.Join(members, ", "))
+		}
+	}
+
+	if uncomparable {
+		// Reverse lookups cannot be trusted for such members; they were reported above.
+		return
+	}
+
+	// Round-trip each unambiguous member through the name and value lookups.
+	h := enum.Wrap(e)
+	for _, value := range order {
+		members := names[value]
+		if len(members) != 1 {
+			continue
+		}
+		parsed, err := h.Parse(members[0])
+		if err != nil || parsed != value {
+			t.Errorf("member %s parses to %v, %v; want %s", members[0], parsed, err, formatValue(value))
+			continue
+		}
+		if name, ok := h.NameOf(parsed); !ok || name != members[0] {
+			t.Errorf("value %s maps back to %q; want %s", formatValue(value), name, members[0])
+		}
+	}
+}
+
+// formatValue renders a value with its type, e.g. int(200).
+func formatValue(value any) string {
+	if s, ok := value.(string); ok {
+		return fmt.Sprintf("%q", s)
+	}
+	return fmt.Sprintf("%T(%v)", value, value)
+}
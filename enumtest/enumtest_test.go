@@ -0,0 +1,88 @@
+package enumtest
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/tnnmigga/enum"
+)
+
+// recorder is a testing.TB that records errors instead of failing the test.
+type recorder struct {
+	testing.TB
+	errors []string
+}
+
+// Helper is a no-op.
+func (r *recorder) Helper() {}
+
+// Errorf records the formatted error.
+func (r *recorder) Errorf(format string, args ...any) {
+	r.errors = append(r.errors, fmt.Sprintf(format, args...))
+}
+
+// TestAssertBijective tests that bijective enums pass and shared values are reported.
+func TestAssertBijective(t *testing.T) {
+	r := &recorder{TB: t}
+	AssertBijective(r, enum.New[struct {
+		Active string
+		Code   struct {
+			StatusOK       int   `enum:"200"`
+			StatusNotFound int   `enum:"404"`
+			Small          uint8 `enum:"200"`
+		}
+	}]())
+	if len(r.errors) != 0 {
+		t.Errorf("AssertBijective() reported %q; want no errors", r.errors)
+	}
+
+	r = &recorder{TB: t}
+	AssertBijective(r, enum.New[struct {
+		OK      int    `enum:"200"`
+		Success int    `enum:"200"`
+		Fine    int    `enum:"200"`
+		Missing int    `enum:"404"`
+		Legacy  string `enum:"ok"`
+		Current string `enum:"ok"`
+	}]())
+	want := []string{
+		"value int(200) is shared by OK, Success, Fine",
+		`value "ok" is shared by Legacy, Current`,
+	}
+	if strings.Join(r.errors, "\n") != strings.Join(want, "\n") {
+		t.Errorf("AssertBijective() reported %q; want %q", r.errors, want)
+	}
+
+	r = &recorder{TB: t}
+	AssertBijective(r, struct {
+		A int
+		B []string
+	}{A: 1, B: []string{"b"}})
+	if len(r.errors) != 1 || !strings.Contains(r.errors[0], "member B: values of type []string cannot be compared") {
+		t.Errorf("AssertBijective() reported %q; want an uncomparable member error", r.errors)
+	}
+
+	r = &recorder{TB: t}
+	shared := enum.New[struct {
+		OK      int `enum:"200"`
+		Success int `enum:"200"`
+		Missing int `enum:"404"`
+	}]()
+	AssertBijective(r, &shared)
+	if len(r.errors) != 1 || r.errors[0] != "value int(200) is shared by OK, Success" {
+		t.Errorf("AssertBijective(&shared) reported %q; want the shared value", r.errors)
+	}
+
+	r = &recorder{TB: t}
+	AssertBijective(r, (*struct{ A int })(nil))
+	if len(r.errors) != 1 || !strings.Contains(r.errors[0], "not a struct") {
+		t.Errorf("AssertBijective(nil pointer) reported %q; want a not-a-struct error", r.errors)
+	}
+
+	r = &recorder{TB: t}
+	AssertBijective(r, 123)
+	if len(r.errors) != 1 || !strings.Contains(r.errors[0], "not a struct") {
+		t.Errorf("AssertBijective(123) reported %q; want a not-a-struct error", r.errors)
+	}
+}
@@ -0,0 +1,51 @@
+package enum
+
+import (
+	"expvar"
+	"fmt"
+	"sync"
+)
+
+// expvars records the names PublishExpvar has published, guarded by expvarsMu.
+var (
+	expvarsMu sync.Mutex
+	expvars   = map[string]bool{}
+)
+
+// PublishExpvar publishes the enums made available with Register as the expvar name,
+// such as "enums", for inspection through the /debug/vars handler. The variable is a
+// JSON object mapping each registry name to an object of the dotted member names and
+// values of that enum, and is computed on every read, so enums registered later appear
+// too. Publishing the same name again is a no-op. Returns an error instead of panicking
+// if name is empty or another variable is already published as name.
+func PublishExpvar(name string) error {
+	if name == "" {
+		return fmt.Errorf("invalid expvar name %q", name)
+	}
+	expvarsMu.Lock()
+	defer expvarsMu.Unlock()
+	if expvars[name] {
+		return nil
+	}
+	if expvar.Get(name) != nil {
+		return fmt.Errorf("expvar %q is already published", name)
+	}
+	expvar.Publish(name, expvar.Func(registeredValues))
+	expvars[name] = true
+	return nil
+}
+
+// registeredValues returns the members of the registered enums by registry name.
+func registeredValues() any {
+	enums := map[string]map[string]any{}
+	registry.Range(func(name, enum any) bool {
+		enumVal, _ := structValue(enum)
+		members := map[string]any{}
+		for _, m := range leaves(enumVal, "enum") {
+			members[m.path] = m.value.Interface()
+		}
+		enums[name.(string)] = members
+		return true
+	})
+	return enums
+}
@@ -0,0 +1,57 @@
+package enum
+
+import (
+	"encoding/json"
+	"expvar"
+	"net/http/httptest"
+	"testing"
+)
+
+// TestPublishExpvar tests that registered enums appear in the expvar handler, including
+// those registered after publishing, and that publishing is idempotent.
+func TestPublishExpvar(t *testing.T) {
+	if err := Register("expvar-before", New[struct {
+		StatusOK int `enum:"200"`
+	}]()); err != nil {
+		t.Fatalf("Register() error = %v", err)
+	}
+	if err := PublishExpvar("enums-test"); err != nil {
+		t.Fatalf("PublishExpvar() error = %v", err)
+	}
+	if err := PublishExpvar("enums-test"); err != nil {
+		t.Errorf("PublishExpvar() again error = %v; want nil", err)
+	}
+	if err := Register("expvar-after", New[struct {
+		Level struct {
+			Low string
+		}
+	}]()); err != nil {
+		t.Fatalf("Register() error = %v", err)
+	}
+
+	rec := httptest.NewRecorder()
+	expvar.Handler().ServeHTTP(rec, httptest.NewRequest("GET", "/debug/vars", nil))
+	var vars struct {
+		Enums map[string]map[string]any `json:"enums-test"`
+	}
+	if err := json.Unmarshal(rec.Body.Bytes(), &vars); err != nil {
+		t.Fatalf("decoding /debug/vars: %v", err)
+	}
+	if got := vars.Enums["expvar-before"]["StatusOK"]; got != float64(200) {
+		t.Errorf("expvar-before.StatusOK = %v; want 200", got)
+	}
+	if got := vars.Enums["expvar-after"]["Level.Low"]; got != "Low" {
+		t.Errorf("expvar-after.Level.Low = %v; want Low", got)
+	}
+}
+
+// TestPublishExpvarCollision tests that names taken by other variables are rejected.
+func TestPublishExpvarCollision(t *testing.T) {
+	expvar.NewInt("enums-taken")
+	if err := PublishExpvar("enums-taken"); err == nil {
+		t.Errorf("PublishExpvar(taken) error = nil; want an error")
+	}
+	if err := PublishExpvar(""); err == nil {
+		t.Errorf("PublishExpvar(\"\") error = nil; want an error")
+	}
+}
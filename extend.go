@@ -0,0 +1,65 @@
+package enum
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Extend initializes enums of types Base and Extra with TryNew and merges their leaf
+// members into a single map from dotted name to value, composing enums across packages
+// where embedding is not convenient. The options apply to both enums. Returns an error
+// if either enum fails to initialize or both define a member with the same name.
+func Extend[Base any, Extra any](opts ...Option) (map[string]any, error) {
+	base, err := TryNew[Base](opts...)
+	if err != nil {
+		return nil, err
+	}
+	extra, err := TryNew[Extra](opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	members := make(map[string]any)
+	for _, e := range Entries(base) {
+		members[e.Name] = e.Value
+	}
+	for _, e := range Entries(extra) {
+		if _, ok := members[e.Name]; ok {
+			return nil, fmt.Errorf("member %s is defined by both %T and %T", e.Name, base, extra)
+		}
+		members[e.Name] = e.Value
+	}
+	return members, nil
+}
+
+// Rename returns the leaf members of enum as a map from dotted name to value, like
+// Extend, with the names that are keys of mapping replaced by their mapped names, so
+// the same enum can be presented under each consumer's naming convention. Unmapped
+// members keep their names. Returns an error if enum is not a struct, if a key of
+// mapping names no member, or if two members would end up with the same name.
+func Rename(enum any, mapping map[string]string) (map[string]any, error) {
+	enumVal, ok := structValue(enum)
+	if !ok {
+		return nil, fmt.Errorf("type %T is not a struct", enum)
+	}
+
+	members := make(map[string]any)
+	from := make(map[string]string)
+	matched := make(map[string]bool)
+	for _, e := range entriesOf(enumVal, "enum") {
+		name := e.Name
+		if mapped, ok := mapping[e.Name]; ok {
+			name = mapped
+			matched[e.Name] = true
+		}
+		if other, ok := from[name]; ok {
+			return nil, fmt.Errorf("member %s renamed to %s collides with member %s", e.Name, name, other)
+		}
+		from[name] = e.Name
+		members[name] = e.Value
+	}
+	if unknown := unmatched(mapping, matched); len(unknown) > 0 {
+		return nil, fmt.Errorf("unknown members: %s", strings.Join(unknown, ", "))
+	}
+	return members, nil
+}
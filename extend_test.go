@@ -0,0 +1,94 @@
+package enum
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// BaseStatus and ExtraStatus are the enums composed by the Extend tests.
+type (
+	BaseStatus struct {
+		StatusOK int `enum:"200"`
+		Reason   struct {
+			Timeout string
+		}
+	}
+	ExtraStatus struct {
+		StatusTeapot int `enum:"418"`
+		Reason       struct {
+			Denied string
+		}
+	}
+)
+
+// TestExtend tests merging the members of two enums.
+func TestExtend(t *testing.T) {
+	got, err := Extend[BaseStatus, ExtraStatus]()
+	if err != nil {
+		t.Fatalf("Extend() error = %v", err)
+	}
+	want := map[string]any{
+		"StatusOK":       200,
+		"Reason.Timeout": "Timeout",
+		"StatusTeapot":   418,
+		"Reason.Denied":  "Denied",
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Extend() = %v; want %v", got, want)
+	}
+}
+
+// TestExtendErrors tests name collisions and initialization failures.
+func TestExtendErrors(t *testing.T) {
+	_, err := Extend[BaseStatus, struct {
+		Reason struct{ Timeout string }
+	}]()
+	if err == nil || !strings.Contains(err.Error(), "member Reason.Timeout is defined by both") {
+		t.Errorf("Extend() error = %v; want collision error", err)
+	}
+	if _, err := Extend[BaseStatus, struct {
+		Bad int8 `enum:"300"`
+	}](); err == nil {
+		t.Error("Extend() with an invalid enum returned nil error")
+	}
+}
+
+// TestRename tests remapping member names.
+func TestRename(t *testing.T) {
+	base := New[BaseStatus]()
+	got, err := Rename(base, map[string]string{"StatusOK": "ok", "Reason.Timeout": "reason_timeout"})
+	if err != nil {
+		t.Fatalf("Rename() error = %v", err)
+	}
+	want := map[string]any{"ok": 200, "reason_timeout": "Timeout"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Rename() = %v; want %v", got, want)
+	}
+
+	got, err = Rename(base, map[string]string{"Reason.Timeout": "timeout"})
+	if want := map[string]any{"StatusOK": 200, "timeout": "Timeout"}; err != nil || !reflect.DeepEqual(got, want) {
+		t.Errorf("Rename() = %v, %v; want unmapped members to keep their names", got, err)
+	}
+}
+
+// TestRenameErrors tests that collisions and unknown members are rejected.
+func TestRenameErrors(t *testing.T) {
+	base := New[BaseStatus]()
+	tests := []struct {
+		mapping map[string]string
+		want    string
+	}{
+		{map[string]string{"Reason.Timeout": "StatusOK"}, "member Reason.Timeout renamed to StatusOK collides with member StatusOK"},
+		{map[string]string{"StatusOK": "x", "Reason.Timeout": "x"}, "member Reason.Timeout renamed to x collides with member StatusOK"},
+		{map[string]string{"Missing": "x"}, "unknown members: Missing"},
+	}
+	for _, tt := range tests {
+		if _, err := Rename(base, tt.mapping); err == nil || err.Error() != tt.want {
+			t.Errorf("Rename(%v) error = %v; want %q", tt.mapping, err, tt.want)
+		}
+	}
+	if _, err := Rename(42, nil); err == nil {
+		t.Error("Rename(42) returned nil error")
+	}
+}
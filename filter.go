@@ -0,0 +1,35 @@
+package enum
+
+// Filter returns the dotted names of the leaf members of enum whose value is of type V
+// and satisfies keep, in declaration order. Returns nil if enum is not a struct.
+func Filter[V any](enum any, keep func(name string, value V) bool) []string {
+	enumVal, ok := structValue(enum)
+	if !ok {
+		return nil
+	}
+
+	var names []string
+	for _, m := range leaves(enumVal, "enum") {
+		if value, ok := m.value.Interface().(V); ok && keep(m.path, value) {
+			names = append(names, m.path)
+		}
+	}
+	return names
+}
+
+// GreaterThan returns the dotted names of the leaf members of type V whose value exceeds
+// threshold, in declaration order.
+func GreaterThan[V ordered](enum any, threshold V) []string {
+	return Filter(enum, func(_ string, value V) bool { return value > threshold })
+}
+
+// LessThan returns the dotted names of the leaf members of type V whose value is below
+// threshold, in declaration order.
+func LessThan[V ordered](enum any, threshold V) []string {
+	return Filter(enum, func(_ string, value V) bool { return value < threshold })
+}
+
+// ordered is satisfied by the types supporting the < and > operators.
+type ordered interface {
+	integer | ~float32 | ~float64 | ~string
+}
@@ -0,0 +1,49 @@
+package enum
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// FilterStatus is the enum used by the filter tests.
+type FilterStatus struct {
+	Name string
+	Code struct {
+		StatusOK          int `enum:"200"`
+		StatusBadRequest  int `enum:"400"`
+		StatusNotFound    int `enum:"404"`
+		StatusServerError int `enum:"500"`
+	}
+	Retries uint8 `enum:"3"`
+}
+
+// TestFilter tests selecting members by type and predicate in declaration order.
+func TestFilter(t *testing.T) {
+	Status := New[FilterStatus]()
+	got := Filter(Status, func(name string, value int) bool { return strings.HasSuffix(name, "Found") || value == 200 })
+	want := []string{"Code.StatusOK", "Code.StatusNotFound"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Filter() = %v; want %v", got, want)
+	}
+	if got := Filter(123, func(string, int) bool { return true }); got != nil {
+		t.Errorf("Filter(123) = %v; want nil", got)
+	}
+}
+
+// TestGreaterThanLessThan tests the threshold queries, which only consider members of type V.
+func TestGreaterThanLessThan(t *testing.T) {
+	Status := New[FilterStatus]()
+	if got, want := GreaterThan(Status, 400), []string{"Code.StatusNotFound", "Code.StatusServerError"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("GreaterThan(400) = %v; want %v", got, want)
+	}
+	if got, want := LessThan(Status, 400), []string{"Code.StatusOK"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("LessThan(400) = %v; want %v", got, want)
+	}
+	if got, want := LessThan(Status, uint8(10)), []string{"Retries"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("LessThan(uint8(10)) = %v; want %v", got, want)
+	}
+	if got := GreaterThan(Status, 1000); got != nil {
+		t.Errorf("GreaterThan(1000) = %v; want nil", got)
+	}
+}
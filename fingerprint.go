@@ -0,0 +1,61 @@
+package enum
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Fingerprint returns a stable hash of the definition of enum, for checking at startup
+// that an enum matches the one known to a database or another service. It is the first
+// 16 hexadecimal digits of the SHA-256 hash of the canonical form of enum, which has one
+// line per leaf member, sorted by dotted name:
+//
+//	<dotted name> TAB <kind> TAB <value> LF
+//
+// where kind is the reflect.Kind of the member's field, such as "int" or "string", and
+// value is a quoted Go string for strings, a decimal number for integers, and the
+// decimal code, a colon, and the quoted label for code-label members. The fingerprint
+// therefore changes when members are renamed, added, or removed, or change kind or value,
+// but not when they are reordered or unexported fields change. The canonical form is
+// part of the package's compatibility promise. Returns "" if enum is not a struct.
+func Fingerprint(enum any) string {
+	enumVal, ok := structValue(enum)
+	if !ok {
+		return ""
+	}
+	sum := sha256.Sum256([]byte(canonicalForm(enumVal)))
+	return hex.EncodeToString(sum[:8])
+}
+
+// canonicalForm returns the canonical form hashed by Fingerprint.
+func canonicalForm(val reflect.Value) string {
+	members := leaves(val, "enum")
+	sort.Slice(members, func(i, j int) bool { return members[i].path < members[j].path })
+
+	var buf strings.Builder
+	for _, m := range members {
+		fmt.Fprintf(&buf, "%s\t%s\t%s\n", m.path, m.field.Type.Kind(), canonicalValue(m.value))
+	}
+	return buf.String()
+}
+
+// canonicalValue renders a member value for the canonical form.
+func canonicalValue(val reflect.Value) string {
+	switch val.Kind() {
+	case reflect.String:
+		return strconv.Quote(val.String())
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return strconv.FormatInt(val.Int(), 10)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return strconv.FormatUint(val.Uint(), 10)
+	}
+	if isCodeLabel(val.Type()) {
+		return canonicalValue(val.FieldByName("Code")) + ":" + canonicalValue(val.FieldByName("Label"))
+	}
+	return fmt.Sprintf("%v", val.Interface())
+}
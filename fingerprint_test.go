@@ -0,0 +1,85 @@
+package enum
+
+import "testing"
+
+// FingerprintStatus is the enum whose fingerprint is pinned by the golden tests.
+type FingerprintStatus struct {
+	Name string
+	Code struct {
+		StatusOK       int   `enum:"200"`
+		StatusNotFound int   `enum:"404"`
+		Retries        uint8 `enum:"3"`
+	}
+	Label CodeLabel `enum:"500:Internal Error"`
+	note  string
+}
+
+// TestCanonicalForm pins the canonical form hashed by Fingerprint.
+func TestCanonicalForm(t *testing.T) {
+	enumVal, _ := structValue(New[FingerprintStatus]())
+	want := "Code.Retries\tuint8\t3\n" +
+		"Code.StatusNotFound\tint\t404\n" +
+		"Code.StatusOK\tint\t200\n" +
+		"Label\tstruct\t500:\"Internal Error\"\n" +
+		"Name\tstring\t\"Name\"\n"
+	if got := canonicalForm(enumVal); got != want {
+		t.Errorf("canonicalForm() =\n%s\nwant\n%s", got, want)
+	}
+}
+
+// TestFingerprint pins the fingerprint and checks what it is sensitive to.
+func TestFingerprint(t *testing.T) {
+	const golden = "3a9f0411e42759a7"
+	base := Fingerprint(New[FingerprintStatus]())
+	if base != golden {
+		t.Errorf("Fingerprint() = %s; want golden %s", base, golden)
+	}
+
+	// Reordering members and changing unexported fields does not matter.
+	reordered := Fingerprint(struct {
+		other int
+		Label CodeLabel
+		Code  struct {
+			Retries        uint8
+			StatusNotFound int
+			StatusOK       int
+		}
+		Name string
+	}{Label: CodeLabel{500, "Internal Error"}, Name: "Name", other: 1, Code: struct {
+		Retries        uint8
+		StatusNotFound int
+		StatusOK       int
+	}{3, 404, 200}})
+	if reordered != base {
+		t.Errorf("Fingerprint() of a reordered enum = %s; want %s", reordered, base)
+	}
+
+	changed := []struct {
+		name string
+		opts []Option
+	}{
+		{"value", []Option{WithOverrides(map[string]any{"Code.StatusOK": 201})}},
+		{"string value", []Option{WithNamingStyle(NamingLower)}},
+	}
+	for _, tt := range changed {
+		if got := Fingerprint(New[FingerprintStatus](tt.opts...)); got == base {
+			t.Errorf("%s: Fingerprint() unchanged", tt.name)
+		}
+	}
+
+	type one struct{ Name string }
+	variants := map[string]any{
+		"renamed member": struct{ Title string }{"Name"},
+		"added member":   struct{ Name, Extra string }{"Name", "Extra"},
+		"removed member": struct{}{},
+		"changed kind":   struct{ Name int }{0},
+	}
+	for name, variant := range variants {
+		if Fingerprint(variant) == Fingerprint(one{"Name"}) {
+			t.Errorf("%s: Fingerprint() unchanged", name)
+		}
+	}
+	if got := Fingerprint(123); got != "" {
+		t.Errorf("Fingerprint(123) = %q; want empty", got)
+	}
+}
@@ -0,0 +1,39 @@
+package enum
+
+import "reflect"
+
+// FitsIn reports whether the value of every integer leaf member of enum fits the integer
+// kind, using the overflow checks applied to tags, and returns the names of the members
+// that do not, in declaration order. It guides narrowing an enum's type, say from int to
+// int16. Non-integer members are ignored; if kind is not an integer kind, no member fits.
+// Returns true and nil if enum is not a struct.
+func FitsIn(enum any, kind reflect.Kind) (bool, []string) {
+	enumVal, ok := structValue(enum)
+	if !ok {
+		return true, nil
+	}
+
+	var overflows []string
+	for _, m := range leaves(enumVal, "enum") {
+		if !isIntegerKind(m.value.Kind()) {
+			continue
+		}
+		if !fits(m.value, kind) {
+			overflows = append(overflows, m.path)
+		}
+	}
+	return len(overflows) == 0, overflows
+}
+
+// fits reports whether the integer value val can be stored in a field of the kind.
+func fits(val reflect.Value, kind reflect.Kind) bool {
+	switch kind {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		n, err := signedValue(val)
+		return err == nil && checkIntOverflow(n, kind) == nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		n, err := unsignedValue(val)
+		return err == nil && checkUintOverflow(n, kind) == nil
+	}
+	return false
+}
@@ -0,0 +1,49 @@
+package enum
+
+import (
+	"reflect"
+	"testing"
+)
+
+// FitsStatus mixes integer widths, signs, and a string member.
+type FitsStatus struct {
+	Code struct {
+		StatusOK    int `enum:"200"`
+		StatusLarge int `enum:"70000"`
+	}
+	Negative int8   `enum:"-1"`
+	Huge     uint64 `enum:"18446744073709551615"`
+	Name     string
+}
+
+// TestFitsIn tests which members fit narrower integer kinds.
+func TestFitsIn(t *testing.T) {
+	status := New[FitsStatus]()
+	tests := []struct {
+		kind reflect.Kind
+		ok   bool
+		want []string
+	}{
+		{reflect.Int64, false, []string{"Huge"}},
+		{reflect.Int32, false, []string{"Huge"}},
+		{reflect.Int16, false, []string{"Code.StatusLarge", "Huge"}},
+		{reflect.Int8, false, []string{"Code.StatusOK", "Code.StatusLarge", "Huge"}},
+		{reflect.Uint64, false, []string{"Negative"}},
+		{reflect.Uint8, false, []string{"Code.StatusLarge", "Negative", "Huge"}},
+		{reflect.String, false, []string{"Code.StatusOK", "Code.StatusLarge", "Negative", "Huge"}},
+	}
+	for _, tt := range tests {
+		ok, got := FitsIn(status, tt.kind)
+		if ok != tt.ok || !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("FitsIn(%s) = %v, %v; want %v, %v", tt.kind, ok, got, tt.ok, tt.want)
+		}
+	}
+
+	small := New[struct {
+		A int `enum:"-5"`
+		B int `enum:"100"`
+	}]()
+	if ok, got := FitsIn(small, reflect.Int8); !ok || got != nil {
+		t.Errorf("FitsIn(Int8) = %v, %v; want true, nil", ok, got)
+	}
+}
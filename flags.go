@@ -0,0 +1,288 @@
+package enum
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+// ParseFlags parses a combination of flag names separated by "|", such as "Read|Write",
+// and returns the bitwise OR of their values. Whitespace around names is ignored and an
+// empty string parses to zero. Names are resolved against the leaf members of enum whose
+// type is V, case-insensitively with WithIgnoreCase. Returns an error listing every
+// unknown name along with the valid names, capped by WithMaxListed.
+func ParseFlags[V integer](enum any, s string, opts ...Option) (V, error) {
+	flags, err := flagMembers[V](enum)
+	if err != nil {
+		return 0, err
+	}
+	if strings.TrimSpace(s) == "" {
+		return 0, nil
+	}
+
+	// Resolve each name, collecting the unknown ones to report them together.
+	var result V
+	var unknown []string
+	for _, name := range strings.Split(s, "|") {
+		name = strings.TrimSpace(name)
+		value, err := parse[V](enum, name, opts)
+		if errors.Is(err, errAmbiguous) {
+			return 0, err
+		}
+		if err != nil {
+			unknown = append(unknown, fmt.Sprintf("%q", name))
+			continue
+		}
+		result |= value
+	}
+	if len(unknown) > 0 {
+		return 0, fmt.Errorf("unknown flags %s; valid flags are %s", strings.Join(unknown, ", "), joinAllowed(flags.names, ", ", buildOptions(opts).MaxListed))
+	}
+	return result, nil
+}
+
+// flagSet holds the names and values of the members of type V, in declaration order.
+type flagSet[V integer] struct {
+	names  []string
+	values []V
+}
+
+// flagMembers collects the leaf members of enum whose type is V.
+func flagMembers[V integer](enum any) (flagSet[V], error) {
+	var flags flagSet[V]
+	enumVal, ok := structValue(enum)
+	if !ok {
+		return flags, fmt.Errorf("type %T is not a struct", enum)
+	}
+
+	targetType := reflect.TypeOf((*V)(nil)).Elem()
+	for _, m := range leaves(enumVal, "enum") {
+		if m.field.Type == targetType {
+			flags.names = append(flags.names, m.path)
+			flags.values = append(flags.values, m.value.Interface().(V))
+		}
+	}
+	return flags, nil
+}
+
+// FormatFlags is the inverse of ParseFlags: it decomposes the integer v into the names of
+// the leaf members of enum, of the same type as v, whose bits are set, joined with "|".
+// A member whose value equals v exactly is preferred over a decomposition, and composite
+// members are preferred over their individual bits. Names appear in declaration order.
+// Zero formats as the name of a zero-valued member if one exists, else "0".
+// Bits not covered by any member are appended as a hexadecimal chunk such as "0x8",
+// or reported as an error when WithStrictFlags is given.
+func FormatFlags(enum any, v any, opts ...Option) (string, error) {
+	o := buildOptions(opts)
+
+	enumVal, ok := structValue(enum)
+	if !ok {
+		return "", fmt.Errorf("type %T is not a struct", enum)
+	}
+	bits, ok := integerBits(reflect.ValueOf(v))
+	if !ok {
+		return "", fmt.Errorf("value %v of type %T is not an integer", v, v)
+	}
+
+	// Collect the members sharing the type of v.
+	type flag struct {
+		name string
+		bits uint64
+	}
+	var flags []flag
+	for _, m := range leaves(enumVal, o.tagKey()) {
+		if m.field.Type == reflect.TypeOf(v) {
+			memberBits, _ := integerBits(m.value)
+			flags = append(flags, flag{m.path, memberBits})
+		}
+	}
+
+	// Prefer a single member matching the whole value, which also covers zero.
+	for _, f := range flags {
+		if f.bits == bits {
+			return f.name, nil
+		}
+	}
+	if bits == 0 {
+		return "0", nil
+	}
+
+	// Greedily take the largest members fully contained in the remaining bits, so that
+	// composite members win over their parts, then report them in declaration order.
+	order := make([]int, len(flags))
+	for i := range order {
+		order[i] = i
+	}
+	sort.SliceStable(order, func(a, b int) bool {
+		return flags[order[a]].bits > flags[order[b]].bits
+	})
+	taken := make([]bool, len(flags))
+	remaining := bits
+	for _, i := range order {
+		if f := flags[i]; f.bits != 0 && remaining&f.bits == f.bits {
+			taken[i] = true
+			remaining &^= f.bits
+		}
+	}
+	var names []string
+	for i, f := range flags {
+		if taken[i] {
+			names = append(names, f.name)
+		}
+	}
+
+	// Report the residual bits that no member covers.
+	if remaining != 0 {
+		if o.StrictFlags {
+			return "", fmt.Errorf("value %#x has unknown bits %#x", bits, remaining)
+		}
+		names = append(names, fmt.Sprintf("%#x", remaining))
+	}
+	return strings.Join(names, "|"), nil
+}
+
+// integerBits returns the bit pattern of an integer value as a uint64.
+// Signed values are reinterpreted in two's complement.
+func integerBits(val reflect.Value) (uint64, bool) {
+	switch val.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return uint64(val.Int()), true
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return val.Uint(), true
+	}
+	return 0, false
+}
+
+// HasFlag reports whether all bits of flag are set in v.
+func HasFlag[V integer](v, flag V) bool {
+	return v&flag == flag
+}
+
+// SetFlag returns v with the bits of flag set.
+func SetFlag[V integer](v, flag V) V {
+	return v | flag
+}
+
+// ClearFlag returns v with the bits of flag cleared.
+func ClearFlag[V integer](v, flag V) V {
+	return v &^ flag
+}
+
+// ToggleFlag returns v with the bits of flag flipped.
+func ToggleFlag[V integer](v, flag V) V {
+	return v ^ flag
+}
+
+// FlagsOf returns the names of the non-zero leaf members of enum, of the same type as v,
+// whose bits are all set in v, in declaration order. Composite members are listed
+// alongside their parts. Returns nil if enum is not a struct or v is not an integer.
+func FlagsOf(enum any, v any) []string {
+	enumVal, ok := structValue(enum)
+	if !ok {
+		return nil
+	}
+	bits, ok := integerBits(reflect.ValueOf(v))
+	if !ok {
+		return nil
+	}
+
+	var names []string
+	for _, m := range leaves(enumVal, "enum") {
+		if m.field.Type != reflect.TypeOf(v) {
+			continue
+		}
+		if memberBits, _ := integerBits(m.value); memberBits != 0 && bits&memberBits == memberBits {
+			names = append(names, m.path)
+		}
+	}
+	return names
+}
+
+// Flags provides the flag helpers bound to the members of an enum of type V.
+// Unlike the free functions, its methods validate that the flag argument is a member
+// of the enum and panic otherwise, catching stray constants during development.
+type Flags[V integer] struct {
+	enum    any
+	members map[V]bool
+}
+
+// FlagsFor returns the validating flag helpers for the leaf members of enum of type V.
+// Panics if enum is not a struct.
+func FlagsFor[V integer](enum any) Flags[V] {
+	flags, err := flagMembers[V](enum)
+	if err != nil {
+		panic(err.Error())
+	}
+
+	members := make(map[V]bool, len(flags.values))
+	for _, value := range flags.values {
+		members[value] = true
+	}
+	return Flags[V]{enum: enum, members: members}
+}
+
+// check panics if flag is not the value of a member.
+func (f Flags[V]) check(flag V) {
+	if !f.members[flag] {
+		panic(fmt.Sprintf("flag %#x is not a member of %T", flag, f.enum))
+	}
+}
+
+// Has is HasFlag, panicking if flag is not a member.
+func (f Flags[V]) Has(v, flag V) bool {
+	f.check(flag)
+	return HasFlag(v, flag)
+}
+
+// Set is SetFlag, panicking if flag is not a member.
+func (f Flags[V]) Set(v, flag V) V {
+	f.check(flag)
+	return SetFlag(v, flag)
+}
+
+// Clear is ClearFlag, panicking if flag is not a member.
+func (f Flags[V]) Clear(v, flag V) V {
+	f.check(flag)
+	return ClearFlag(v, flag)
+}
+
+// Toggle is ToggleFlag, panicking if flag is not a member.
+func (f Flags[V]) Toggle(v, flag V) V {
+	f.check(flag)
+	return ToggleFlag(v, flag)
+}
+
+// ValidateFlags checks that the integer leaf members of enum can be combined as flags:
+// each must be zero or a power of two, and no two members may share a bit. Members of
+// other kinds are ignored. Returns an error naming every offending member, or nil.
+func ValidateFlags(enum any) error {
+	enumVal, ok := structValue(enum)
+	if !ok {
+		return fmt.Errorf("type %T is not a struct", enum)
+	}
+
+	// owners maps each bit already claimed to the member that claimed it.
+	owners := map[uint64]string{}
+	var problems []string
+	for _, m := range leaves(enumVal, "enum") {
+		bits, ok := integerBits(m.value)
+		if !ok || bits == 0 {
+			continue
+		}
+		if bits&(bits-1) != 0 {
+			problems = append(problems, fmt.Sprintf("%s (%#x) is not a power of two", m.path, bits))
+			continue
+		}
+		if owner, ok := owners[bits]; ok {
+			problems = append(problems, fmt.Sprintf("%s and %s share bit %#x", owner, m.path, bits))
+			continue
+		}
+		owners[bits] = m.path
+	}
+	if len(problems) > 0 {
+		return fmt.Errorf("invalid flags: %s", strings.Join(problems, "; "))
+	}
+	return nil
+}
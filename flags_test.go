@@ -0,0 +1,197 @@
+package enum
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// Permission is a flag enum used by the flag tests.
+var Permission = New[struct {
+	Read  uint8 `enum:"1"`
+	Write uint8 `enum:"2"`
+	Exec  uint8 `enum:"4"`
+	Label string
+}]()
+
+// TestParseFlags tests ParseFlags with single names, combinations, and duplicates.
+func TestParseFlags(t *testing.T) {
+	tests := []struct {
+		input string
+		want  uint8
+	}{
+		{"", 0},
+		{"   ", 0},
+		{"Read", 1},
+		{"Read|Write", 3},
+		{" Read | Write |Exec ", 7},
+		{"Write|Write", 2},
+	}
+	for _, tt := range tests {
+		got, err := ParseFlags[uint8](Permission, tt.input)
+		if err != nil || got != tt.want {
+			t.Errorf("ParseFlags(%q) = %d, %v; want %d, nil", tt.input, got, err, tt.want)
+		}
+	}
+}
+
+// TestParseFlagsUnknown tests that unknown names are reported together with the valid set.
+func TestParseFlagsUnknown(t *testing.T) {
+	_, err := ParseFlags[uint8](Permission, "Read|Delete|Label")
+	if err == nil {
+		t.Fatal("ParseFlags returned nil error; want unknown flag error")
+	}
+	for _, want := range []string{`"Delete"`, `"Label"`, "Read, Write, Exec"} {
+		if !strings.Contains(err.Error(), want) {
+			t.Errorf("ParseFlags error = %q; want it to contain %q", err, want)
+		}
+	}
+}
+
+// Access is a flag enum with a zero member and a composite member.
+var Access = New[struct {
+	None      uint16 `enum:"0"`
+	Read      uint16 `enum:"1"`
+	Write     uint16 `enum:"2"`
+	Exec      uint16 `enum:"4"`
+	ReadWrite uint16 `enum:"3"`
+}]()
+
+// TestFormatFlags tests exact matches, composites, decomposition, zero, and residual bits.
+func TestFormatFlags(t *testing.T) {
+	tests := []struct {
+		enum  any
+		value any
+		want  string
+	}{
+		{Access, uint16(0), "None"},
+		{Permission, uint8(0), "0"},
+		{Access, uint16(4), "Exec"},
+		{Access, uint16(3), "ReadWrite"},
+		{Access, uint16(7), "Exec|ReadWrite"},
+		{Permission, uint8(5), "Read|Exec"},
+		{Permission, uint8(13), "Read|Exec|0x8"},
+	}
+	for _, tt := range tests {
+		got, err := FormatFlags(tt.enum, tt.value)
+		if err != nil || got != tt.want {
+			t.Errorf("FormatFlags(%v) = %q, %v; want %q, nil", tt.value, got, err, tt.want)
+		}
+	}
+}
+
+// TestFormatFlagsStrict tests that residual bits are an error with WithStrictFlags only.
+func TestFormatFlagsStrict(t *testing.T) {
+	if _, err := FormatFlags(Permission, uint8(13), WithStrictFlags()); err == nil || !strings.Contains(err.Error(), "0x8") {
+		t.Errorf("FormatFlags strict error = %v; want unknown bits 0x8 error", err)
+	}
+	if got, err := FormatFlags(Permission, uint8(13), WithStrict()); err != nil || got != "Read|Exec|0x8" {
+		t.Errorf("FormatFlags(WithStrict) = %q, %v; want the residual bits appended", got, err)
+	}
+	if got, err := FormatFlags(Permission, uint8(3), WithStrictFlags()); err != nil || got != "Read|Write" {
+		t.Errorf("FormatFlags strict = %q, %v; want %q, nil", got, err, "Read|Write")
+	}
+	if _, err := FormatFlags(Permission, "Read"); err == nil {
+		t.Errorf("FormatFlags with string value returned nil error; want error")
+	}
+}
+
+// TestFormatParseFlagsRoundTrip tests that formatted flags parse back to the same value.
+func TestFormatParseFlagsRoundTrip(t *testing.T) {
+	for v := uint8(0); v < 8; v++ {
+		s, err := FormatFlags(Permission, v)
+		if err != nil {
+			t.Fatalf("FormatFlags(%d) returned error: %v", v, err)
+		}
+		if s == "0" {
+			s = ""
+		}
+		if got, err := ParseFlags[uint8](Permission, s); err != nil || got != v {
+			t.Errorf("ParseFlags(FormatFlags(%d) = %q) = %d, %v; want %d", v, s, got, err, v)
+		}
+	}
+}
+
+// Wide is a flag enum over int64 using the high bit range.
+var Wide = New[struct {
+	Low  int64 `enum:"1"`
+	High int64 `enum:"4611686018427387904"`
+}]()
+
+// TestFlagHelpers tests the generic flag helpers over uint8 and int64 widths.
+func TestFlagHelpers(t *testing.T) {
+	v := SetFlag(Permission.Read, Permission.Exec)
+	if v != 5 || !HasFlag(v, Permission.Exec) || HasFlag(v, Permission.Write) {
+		t.Errorf("SetFlag(Read, Exec) = %d; want 5 with Exec set and Write clear", v)
+	}
+	if got := ClearFlag(v, Permission.Read); got != 4 {
+		t.Errorf("ClearFlag(5, Read) = %d; want 4", got)
+	}
+	if got := ToggleFlag(ToggleFlag(v, Permission.Write), Permission.Exec); got != 3 {
+		t.Errorf("ToggleFlag twice = %d; want 3", got)
+	}
+
+	w := SetFlag(Wide.Low, Wide.High)
+	if !HasFlag(w, Wide.High) || ClearFlag(w, Wide.High) != Wide.Low {
+		t.Errorf("int64 flag helpers on %#x returned wrong results", w)
+	}
+}
+
+// TestFlagsOf tests listing the names of set flags, including composites.
+func TestFlagsOf(t *testing.T) {
+	if got, want := FlagsOf(Access, uint16(7)), []string{"Read", "Write", "Exec", "ReadWrite"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("FlagsOf(7) = %v; want %v", got, want)
+	}
+	if got := FlagsOf(Access, uint16(0)); got != nil {
+		t.Errorf("FlagsOf(0) = %v; want nil", got)
+	}
+	if got, want := FlagsOf(Wide, Wide.High|Wide.Low), []string{"Low", "High"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("FlagsOf(int64) = %v; want %v", got, want)
+	}
+}
+
+// TestFlagsForStrict tests that the bound helpers reject non-member flags.
+func TestFlagsForStrict(t *testing.T) {
+	flags := FlagsFor[uint8](Permission)
+	if got := flags.Set(Permission.Read, Permission.Write); got != 3 {
+		t.Errorf("Set(Read, Write) = %d; want 3", got)
+	}
+	if !flags.Has(3, Permission.Write) || flags.Clear(3, Permission.Write) != 1 || flags.Toggle(1, Permission.Exec) != 5 {
+		t.Errorf("bound helpers returned wrong results")
+	}
+
+	defer func() {
+		r := recover()
+		if r == nil || !strings.Contains(fmt.Sprint(r), "0x8") {
+			t.Errorf("Has with non-member flag panicked with %v; want panic mentioning 0x8", r)
+		}
+	}()
+	flags.Has(3, 8)
+}
+
+// TestValidateFlags tests that composite and overlapping members are reported by name.
+func TestValidateFlags(t *testing.T) {
+	if err := ValidateFlags(Permission); err != nil {
+		t.Errorf("ValidateFlags(Permission) = %v; want nil", err)
+	}
+	err := ValidateFlags(Access)
+	if err == nil || err.Error() != "invalid flags: ReadWrite (0x3) is not a power of two" {
+		t.Errorf("ValidateFlags(Access) = %v", err)
+	}
+
+	overlapping := New[struct {
+		Read  int `enum:"1"`
+		Write int `enum:"2"`
+		Exec  int `enum:"2"`
+		Sign  int `enum:"-1"`
+	}]()
+	err = ValidateFlags(overlapping)
+	want := "invalid flags: Write and Exec share bit 0x2; Sign (0xffffffffffffffff) is not a power of two"
+	if err == nil || err.Error() != want {
+		t.Errorf("ValidateFlags(overlapping) = %v; want %s", err, want)
+	}
+	if err := ValidateFlags(1); err == nil {
+		t.Errorf("ValidateFlags(1) = nil; want an error")
+	}
+}
@@ -0,0 +1,121 @@
+package enum
+
+import (
+	"fmt"
+	"io"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// Format renders enum as an aligned, multi-line listing in declaration order. Each
+// member is written as "Name = value", with string values quoted; each nested group is
+// written as its name followed by its members indented by two spaces. The "=" signs of
+// consecutive members of a group are aligned. With WithDisplayNames, members are listed by
+// their Display label instead. Returns "" if enum is not a struct.
+//
+// For example:
+//
+//	Code
+//	  StatusOK       = 200
+//	  StatusNotFound = 404
+//	Default = "Default"
+func Format(enum any, opts ...Option) string {
+	root := Tree(enum)
+	if root == nil {
+		return ""
+	}
+	if o := buildOptions(opts); o.UseDisplayNames {
+		relabel(root.Children, "", DisplayNames(enum, opts...))
+	}
+
+	var buf strings.Builder
+	writeNodes(&buf, root.Children, "")
+	return buf.String()
+}
+
+// WriteTo streams the leaf members of enum to w in declaration order, one
+// "dotted.name\tvalue\n" line each, without building the whole listing in memory.
+// Values are written unquoted, as ToURLValues formats them. It returns the number of
+// bytes written and the first write error, or an error if enum is not a struct.
+func WriteTo(enum any, w io.Writer) (int64, error) {
+	enumVal, ok := structValue(enum)
+	if !ok {
+		return 0, fmt.Errorf("type %T is not a struct", enum)
+	}
+
+	var written int64
+	for _, m := range leaves(enumVal, "enum") {
+		n, err := io.WriteString(w, m.path+"\t"+formatParam(m.value)+"\n")
+		written += int64(n)
+		if err != nil {
+			return written, err
+		}
+	}
+	return written, nil
+}
+
+// writeNodes writes the nodes at one level of the tree with the given indentation.
+func writeNodes(buf *strings.Builder, nodes []*Node, indent string) {
+	for i := 0; i < len(nodes); i++ {
+		if nodes[i].Children != nil || nodes[i].Value == nil {
+			buf.WriteString(indent + nodes[i].Name + "\n")
+			writeNodes(buf, nodes[i].Children, indent+"  ")
+			continue
+		}
+
+		// Align the run of consecutive members starting at i.
+		end := i
+		width := 0
+		for ; end < len(nodes) && nodes[end].Children == nil && nodes[end].Value != nil; end++ {
+			if len(nodes[end].Name) > width {
+				width = len(nodes[end].Name)
+			}
+		}
+		for _, n := range nodes[i:end] {
+			fmt.Fprintf(buf, "%s%-*s = %s\n", indent, width, n.Name, formatValue(n.Value))
+		}
+		i = end - 1
+	}
+}
+
+// relabel renames the leaf nodes below prefix to their labels, keyed by dotted name.
+func relabel(nodes []*Node, prefix string, labels map[string]string) {
+	for _, n := range nodes {
+		path := n.Name
+		if prefix != "" {
+			path = prefix + "." + n.Name
+		}
+		if n.Children != nil {
+			relabel(n.Children, path, labels)
+		} else if label, ok := labels[path]; ok {
+			n.Name = label
+		}
+	}
+}
+
+// formatValue renders a member value, quoting strings.
+func formatValue(value any) string {
+	val := reflect.ValueOf(value)
+	switch val.Kind() {
+	case reflect.String:
+		return strconv.Quote(val.String())
+	case reflect.Struct:
+		return fmt.Sprintf("%+v", value)
+	}
+	return fmt.Sprint(value)
+}
+
+// goString renders the Go-syntax representation of a handle: the handle's type name
+// around the indented Format listing of its enum.
+func goString(handle string, enum any) string {
+	var buf strings.Builder
+	buf.WriteString(handle + "{\n")
+	for _, line := range strings.SplitAfter(Format(enum), "\n") {
+		if line != "" {
+			buf.WriteString("\t" + line)
+		}
+	}
+	buf.WriteString("}")
+	return buf.String()
+}
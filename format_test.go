@@ -0,0 +1,132 @@
+package enum
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+)
+
+// FormatStatus is a nested mixed-kind enum used by the formatting tests.
+type FormatStatus struct {
+	Code struct {
+		StatusOK       int `enum:"200"`
+		StatusNotFound int `enum:"404"`
+		Detail         struct {
+			Retries uint8 `enum:"3"`
+		}
+		StatusTeapot int `enum:"418"`
+	}
+	Quoted string `enum:"say \"hi\""`
+	Name   string
+}
+
+// TestFormat tests the aligned listing of a nested mixed-kind enum.
+func TestFormat(t *testing.T) {
+	want := `Code
+  StatusOK       = 200
+  StatusNotFound = 404
+  Detail
+    Retries = 3
+  StatusTeapot = 418
+Quoted = "say \"hi\""
+Name   = "Name"
+`
+	if got := Format(New[FormatStatus]()); got != want {
+		t.Errorf("Format() =\n%s\nwant\n%s", got, want)
+	}
+	if got := Format(123); got != "" {
+		t.Errorf("Format(123) = %q; want empty", got)
+	}
+}
+
+// TestFormatHandles tests that the handle types implement fmt.Stringer and fmt.GoStringer.
+func TestFormatHandles(t *testing.T) {
+	type Small struct {
+		A int `enum:"1"`
+		B string
+	}
+	listing := "A = 1\nB = \"B\"\n"
+
+	h := Of[Small]()
+	if got := fmt.Sprint(h); got != listing {
+		t.Errorf("fmt.Sprint(Enum) = %q; want %q", got, listing)
+	}
+	if got, want := fmt.Sprintf("%#v", h), "enum.Enum[enum.Small]{\n\tA = 1\n\tB = \"B\"\n}"; got != want {
+		t.Errorf("fmt.Sprintf(%%#v, Enum) = %q; want %q", got, want)
+	}
+
+	f := Freeze(New[Small]())
+	if got := fmt.Sprint(f); got != listing {
+		t.Errorf("fmt.Sprint(Frozen) = %q; want %q", got, listing)
+	}
+	if got, want := fmt.Sprintf("%#v", f), `enum.Freeze(enum.Small{A:1, B:"B"})`; got != want {
+		t.Errorf("fmt.Sprintf(%%#v, Frozen) = %q; want %q", got, want)
+	}
+}
+
+// MixedKinds is a struct of every kind the output helpers render. New does not accept
+// bool or float fields, so the tests use a literal.
+type MixedKinds struct {
+	Enabled  bool
+	Retries  int
+	Capacity uint16
+	Ratio    float64
+	Name     string
+}
+
+// mixedKinds is the instance rendered by the output-helper tests.
+var mixedKinds = MixedKinds{Enabled: true, Retries: -3, Capacity: 512, Ratio: 0.25, Name: "primary"}
+
+// TestFormatMixedKinds locks the rendering of bool, int, uint, float, and string members.
+func TestFormatMixedKinds(t *testing.T) {
+	want := "Enabled  = true\n" +
+		"Retries  = -3\n" +
+		"Capacity = 512\n" +
+		"Ratio    = 0.25\n" +
+		"Name     = \"primary\"\n"
+	if got := Format(mixedKinds); got != want {
+		t.Errorf("Format() =\n%s\nwant\n%s", got, want)
+	}
+}
+
+// TestWriteTo tests streaming members as tab-separated lines.
+func TestWriteTo(t *testing.T) {
+	var buf strings.Builder
+	n, err := WriteTo(New[FormatStatus](), &buf)
+	want := "Code.StatusOK\t200\n" +
+		"Code.StatusNotFound\t404\n" +
+		"Code.Detail.Retries\t3\n" +
+		"Code.StatusTeapot\t418\n" +
+		"Quoted\tsay \"hi\"\n" +
+		"Name\tName\n"
+	if err != nil || buf.String() != want || n != int64(len(want)) {
+		t.Errorf("WriteTo() = %d, %v, writing\n%s\nwant %d, nil, writing\n%s", n, err, buf.String(), len(want), want)
+	}
+
+	// Writing stops at the first error, reporting the bytes written so far.
+	w := &failingWriter{limit: 2}
+	n, err = WriteTo(New[FormatStatus](), w)
+	if err != errWriteFailed || n != int64(len("Code.StatusOK\t200\nCode.StatusNotFound\t404\n")) {
+		t.Errorf("WriteTo(failing) = %d, %v; want the two lines written and the write error", n, err)
+	}
+	if _, err := WriteTo(42, &buf); err == nil {
+		t.Error("WriteTo(42) returned nil error")
+	}
+}
+
+// errWriteFailed is returned by failingWriter once its limit is reached.
+var errWriteFailed = errors.New("write failed")
+
+// failingWriter accepts limit writes, then fails.
+type failingWriter struct {
+	limit int
+}
+
+func (w *failingWriter) Write(p []byte) (int, error) {
+	if w.limit == 0 {
+		return 0, errWriteFailed
+	}
+	w.limit--
+	return len(p), nil
+}
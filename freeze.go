@@ -0,0 +1,89 @@
+package enum
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// Frozen is a read-only view of an enum backed by a private deep copy taken by Freeze.
+// Code holding the original struct can still assign to its fields, but the view keeps
+// serving the values recorded at freeze time, and Verify detects the drift.
+// A Frozen is immutable and safe for concurrent use.
+type Frozen struct {
+	typ reflect.Type
+	idx *index
+}
+
+// Freeze returns a read-only view of enum. Panics if enum is not a struct.
+func Freeze(enum any) Frozen {
+	enumVal, ok := structValue(enum)
+	if !ok {
+		panic(fmt.Sprintf("type %T is not a struct", enum))
+	}
+
+	// Copy the enum so that later mutations of the original are not observed.
+	copied := reflect.New(enumVal.Type()).Elem()
+	copied.Set(enumVal)
+	deepCopy(copied)
+	return Frozen{typ: enumVal.Type(), idx: newIndex(copied)}
+}
+
+// Get returns the frozen value of the member with the given dotted name.
+func (f Frozen) Get(name string) (any, bool) {
+	value, err := f.idx.parse(name)
+	return value, err == nil
+}
+
+// Keys returns the dotted names of the members in declaration order.
+func (f Frozen) Keys() []string {
+	return f.idx.names()
+}
+
+// Values returns the frozen values of the members in declaration order.
+func (f Frozen) Values() []any {
+	return f.idx.values()
+}
+
+// Contains reports whether the enum has a member with the given dotted name.
+func (f Frozen) Contains(name string) bool {
+	_, err := f.idx.lookup(name)
+	return err == nil
+}
+
+// Entries returns the frozen members in declaration order.
+func (f Frozen) Entries() []Entry {
+	return append([]Entry(nil), f.idx.entries...)
+}
+
+// Verify reports whether the live enum original still holds the values recorded when
+// frozen was created. It returns an error listing every member whose value drifted,
+// which makes it suitable as a startup or periodic assertion in long-running services.
+func Verify(original any, frozen Frozen) error {
+	enumVal, ok := structValue(original)
+	if !ok || enumVal.Type() != frozen.typ {
+		return fmt.Errorf("type %T does not match frozen type %s", original, frozen.typ)
+	}
+
+	var drifted []string
+	for i, e := range entriesOf(enumVal, "enum") {
+		if want := frozen.idx.entries[i].Value; !reflect.DeepEqual(e.Value, want) {
+			drifted = append(drifted, fmt.Sprintf("%s is %v, want %v", e.Name, e.Value, want))
+		}
+	}
+	if len(drifted) > 0 {
+		return fmt.Errorf("enum %s was modified: %s", frozen.typ, strings.Join(drifted, "; "))
+	}
+	return nil
+}
+
+// String returns the Format listing of the frozen values.
+func (f Frozen) String() string {
+	return Format(f.idx.val.Interface())
+}
+
+// GoString returns a Go expression that rebuilds f: a call to Freeze with the frozen
+// struct as a composite literal, such as enum.Freeze(pkg.Status{OK:200}).
+func (f Frozen) GoString() string {
+	return fmt.Sprintf("enum.Freeze(%#v)", f.idx.val.Interface())
+}
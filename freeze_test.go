@@ -0,0 +1,48 @@
+package enum
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// TestFreezeVerify tests that Verify catches a corrupted field while the view keeps
+// serving the original values.
+func TestFreezeVerify(t *testing.T) {
+	HttpStatus := New[struct {
+		Code struct {
+			StatusOK       int `enum:"200"`
+			StatusNotFound int `enum:"404"`
+		}
+		Default string
+	}]()
+	frozen := Freeze(HttpStatus)
+	if err := Verify(HttpStatus, frozen); err != nil {
+		t.Fatalf("Verify() on untouched enum = %v; want nil", err)
+	}
+
+	HttpStatus.Code.StatusOK = 299
+	err := Verify(HttpStatus, frozen)
+	if err == nil || !strings.Contains(err.Error(), "Code.StatusOK is 299, want 200") {
+		t.Errorf("Verify() = %v; want drift error for Code.StatusOK", err)
+	}
+	if v, ok := frozen.Get("Code.StatusOK"); !ok || v != 200 {
+		t.Errorf("Get(%q) = %v, %v; want 200, true", "Code.StatusOK", v, ok)
+	}
+
+	if got, want := frozen.Keys(), []string{"Code.StatusOK", "Code.StatusNotFound", "Default"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("Keys() = %v; want %v", got, want)
+	}
+	if got, want := frozen.Values(), []any{200, 404, "Default"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("Values() = %v; want %v", got, want)
+	}
+	if !frozen.Contains("Default") || frozen.Contains("Code") {
+		t.Errorf("Contains() returned wrong results")
+	}
+	if got := frozen.Entries(); len(got) != 3 || got[0].Value != 200 {
+		t.Errorf("Entries() = %v; want three entries starting with 200", got)
+	}
+	if err := Verify(123, frozen); err == nil {
+		t.Errorf("Verify(123) = nil; want type mismatch error")
+	}
+}
@@ -0,0 +1,242 @@
+package enum
+
+import (
+	"fmt"
+	"go/format"
+	"path"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// GenerateSource initializes an enum of type T with TryNew and returns Go source for
+// package pkg declaring a package-level variable varName holding the resolved enum as a
+// composite literal, so that the snapshot can be committed and used without reflection
+// at startup. Anonymous struct types, including nested groups, are spelled out with their
+// tags; named types from other packages are imported and qualified, with the imports
+// renamed where package names collide. pkg is the target's import path, or only its
+// name, in which case named types of any package with that name are taken as local.
+// Unexported fields are omitted, and entries sentinels hold their entries as filled by
+// New. Returns an error if initialization fails or a member has a kind that cannot be
+// written as a literal.
+func GenerateSource[T any](pkg, varName string, opts ...Option) (string, error) {
+	enum, err := TryNew[T](opts...)
+	if err != nil {
+		return "", err
+	}
+
+	g := newGenerator(pkg)
+	val := reflect.ValueOf(enum)
+	typ := g.typeExpr(val.Type())
+	lit, err := g.literal(val, "")
+	if err != nil {
+		return "", err
+	}
+
+	var buf strings.Builder
+	buf.WriteString("// Code generated by enum.GenerateSource; DO NOT EDIT.\n\n")
+	buf.WriteString("package " + g.pkg + "\n\n")
+	if len(g.imports) > 0 {
+		paths := make([]string, 0, len(g.imports))
+		for importPath := range g.imports {
+			paths = append(paths, importPath)
+		}
+		sort.Strings(paths)
+		buf.WriteString("import (\n")
+		for _, importPath := range paths {
+			if name := g.imports[importPath]; name != path.Base(importPath) {
+				buf.WriteString(name + " ")
+			}
+			buf.WriteString(strconv.Quote(importPath) + "\n")
+		}
+		buf.WriteString(")\n\n")
+	}
+	fmt.Fprintf(&buf, "var %s = %s%s\n", varName, typ, lit)
+
+	src, err := format.Source([]byte(buf.String()))
+	if err != nil {
+		return "", fmt.Errorf("formatting generated source: %v", err)
+	}
+	return string(src), nil
+}
+
+// generator accumulates the imports needed by generated source.
+type generator struct {
+	pkg     string            // name of the target package
+	pkgPath string            // import path of the target package, if known
+	imports map[string]string // import path to the name it is imported as
+
+	// omitEntries leaves entries sentinel fields out of struct types, so that the
+	// generated source does not need package enum.
+	omitEntries bool
+}
+
+// newGenerator returns a generator for the package with the import path or name pkg.
+func newGenerator(pkg string) *generator {
+	g := &generator{pkg: pkg, imports: map[string]string{}}
+	if strings.Contains(pkg, "/") {
+		g.pkg, g.pkgPath = path.Base(pkg), pkg
+	}
+	return g
+}
+
+// typeExpr returns the Go expression for the type t as seen from package g.pkg.
+func (g *generator) typeExpr(t reflect.Type) string {
+	if t.Name() != "" {
+		if t.PkgPath() == "" {
+			return t.Name()
+		}
+		// reflect qualifies named types with their package name, e.g. "enum.CodeLabel".
+		name, _, _ := strings.Cut(t.String(), ".")
+		if t.PkgPath() == g.pkgPath || (g.pkgPath == "" && name == g.pkg) {
+			return t.Name()
+		}
+		return g.importName(t.PkgPath(), name) + "." + t.Name()
+	}
+
+	if t.Kind() == reflect.Slice {
+		return "[]" + g.typeExpr(t.Elem())
+	}
+	if t.Kind() == reflect.Ptr {
+		return "*" + g.typeExpr(t.Elem())
+	}
+	if t.Kind() != reflect.Struct {
+		return t.String()
+	}
+	return g.structExpr(t)
+}
+
+// importName records an import of the package with the import path importPath and
+// package name name, and returns the name it is imported as: name itself, or name with a
+// number appended if another import or the target package already uses name.
+func (g *generator) importName(importPath, name string) string {
+	if imported, ok := g.imports[importPath]; ok {
+		return imported
+	}
+	taken := make(map[string]bool, len(g.imports)+1)
+	taken[g.pkg] = true
+	for _, imported := range g.imports {
+		taken[imported] = true
+	}
+	alias := name
+	for n := 2; taken[alias]; n++ {
+		alias = name + strconv.Itoa(n)
+	}
+	g.imports[importPath] = alias
+	return alias
+}
+
+// structExpr returns the Go expression for the struct type t spelled out as a struct
+// literal type, even if t is named.
+func (g *generator) structExpr(t reflect.Type) string {
+	var buf strings.Builder
+	buf.WriteString("struct {\n")
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if g.omitEntries && field.Type == entriesType {
+			continue
+		}
+		if !field.Anonymous {
+			buf.WriteString(field.Name + " ")
+		}
+		buf.WriteString(g.typeExpr(field.Type))
+		if field.Tag != "" {
+			buf.WriteString(" " + quoteTag(string(field.Tag)))
+		}
+		buf.WriteString("\n")
+	}
+	buf.WriteString("}")
+	return buf.String()
+}
+
+// literal returns the Go literal for val, without the type for structs. path names val
+// in error messages.
+func (g *generator) literal(val reflect.Value, path string) (string, error) {
+	switch val.Kind() {
+	case reflect.String:
+		return strconv.Quote(val.String()), nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return strconv.FormatInt(val.Int(), 10), nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return strconv.FormatUint(val.Uint(), 10), nil
+	case reflect.Struct:
+		var buf strings.Builder
+		buf.WriteString("{\n")
+		typ := val.Type()
+		for i := 0; i < val.NumField(); i++ {
+			field := typ.Field(i)
+			if !field.IsExported() || (field.Type == entriesType && (g.omitEntries || val.Field(i).IsNil())) {
+				continue
+			}
+			fieldPath := field.Name
+			if path != "" {
+				fieldPath = path + "." + field.Name
+			}
+			var lit string
+			var err error
+			if field.Type == entriesType {
+				lit, err = g.entriesLiteral(val.Field(i), fieldPath)
+			} else {
+				lit, err = g.literal(val.Field(i), fieldPath)
+			}
+			if err != nil {
+				return "", err
+			}
+			if val.Field(i).Kind() == reflect.Struct {
+				lit = g.typeExpr(field.Type) + lit
+			}
+			buf.WriteString(field.Name + ": " + lit + ",\n")
+		}
+		buf.WriteString("}")
+		return buf.String(), nil
+	}
+	return "", fmt.Errorf("field %s: cannot generate a literal of type %s", path, val.Type())
+}
+
+// entriesLiteral returns the Go literal for the entries sentinel val, including its
+// type, with each value converted to the type of its member.
+func (g *generator) entriesLiteral(val reflect.Value, path string) (string, error) {
+	var buf strings.Builder
+	buf.WriteString(g.typeExpr(entriesType) + "{\n")
+	for i := 0; i < val.Len(); i++ {
+		e := val.Index(i).Interface().(Entry)
+		value, err := g.typedLiteral(reflect.ValueOf(e.Value), path+"."+e.Name)
+		if err != nil {
+			return "", err
+		}
+		fmt.Fprintf(&buf, "{Name: %s, Value: %s", strconv.Quote(e.Name), value)
+		if e.Tag != "" {
+			fmt.Fprintf(&buf, ", Tag: %s", strconv.Quote(e.Tag))
+		}
+		buf.WriteString("},\n")
+	}
+	buf.WriteString("}")
+	return buf.String(), nil
+}
+
+// typedLiteral returns the Go literal for val that keeps its type when stored in an
+// interface: structs carry their type, and constants other than int and string values
+// are converted.
+func (g *generator) typedLiteral(val reflect.Value, path string) (string, error) {
+	lit, err := g.literal(val, path)
+	if err != nil {
+		return "", err
+	}
+	switch typ := val.Type(); {
+	case val.Kind() == reflect.Struct:
+		return g.typeExpr(typ) + lit, nil
+	case typ == reflect.TypeOf(0) || typ == reflect.TypeOf(""):
+		return lit, nil
+	default:
+		return g.typeExpr(typ) + "(" + lit + ")", nil
+	}
+}
+
+// quoteTag returns a struct tag as a Go string literal, preferring a raw string.
+func quoteTag(tag string) string {
+	if strings.Contains(tag, "`") {
+		return strconv.Quote(tag)
+	}
+	return "`" + tag + "`"
+}
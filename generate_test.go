@@ -0,0 +1,153 @@
+package enum
+
+import (
+	"go/ast"
+	"go/importer"
+	"go/parser"
+	"go/token"
+	"go/types"
+	"reflect"
+	"strings"
+	"testing"
+
+	akind "github.com/tnnmigga/enum/testdata/gen/a/kind"
+	bkind "github.com/tnnmigga/enum/testdata/gen/b/kind"
+)
+
+// TestGenerateSource tests the generated source for named and anonymous nested enums.
+func TestGenerateSource(t *testing.T) {
+	src, err := GenerateSource[struct {
+		Default string
+		Code    struct {
+			StatusOK int `enum:"200"`
+		}
+		Label CodeLabel `enum:"404:Not Found"`
+	}]("status", "Status")
+	if err != nil {
+		t.Fatalf("GenerateSource() error = %v", err)
+	}
+	want := "// Code generated by enum.GenerateSource; DO NOT EDIT.\n" +
+		"\n" +
+		"package status\n" +
+		"\n" +
+		"import (\n" +
+		"\t\"github.com/tnnmigga/enum\"\n" +
+		")\n" +
+		"\n" +
+		"var Status = struct {\n" +
+		"\tDefault string\n" +
+		"\tCode    struct {\n" +
+		"\t\tStatusOK int `enum:\"200\"`\n" +
+		"\t}\n" +
+		"\tLabel enum.CodeLabel `enum:\"404:Not Found\"`\n" +
+		"}{\n" +
+		"\tDefault: \"Default\",\n" +
+		"\tCode: struct {\n" +
+		"\t\tStatusOK int `enum:\"200\"`\n" +
+		"\t}{\n" +
+		"\t\tStatusOK: 200,\n" +
+		"\t},\n" +
+		"\tLabel: enum.CodeLabel{\n" +
+		"\t\tCode:  404,\n" +
+		"\t\tLabel: \"Not Found\",\n" +
+		"\t},\n" +
+		"}\n"
+	if src != want {
+		t.Errorf("GenerateSource() =\n%s\nwant\n%s", src, want)
+	}
+
+	// Named types of the target package are not qualified.
+	src, err = GenerateSource[FormatStatus]("enum", "Status")
+	if err != nil {
+		t.Fatalf("GenerateSource[FormatStatus]() error = %v", err)
+	}
+	if !strings.Contains(src, "var Status = FormatStatus{") || strings.Contains(src, "import") {
+		t.Errorf("GenerateSource[FormatStatus]() =\n%s\nwant unqualified FormatStatus literal", src)
+	}
+	if _, err := parser.ParseFile(token.NewFileSet(), "status.go", src, 0); err != nil {
+		t.Errorf("generated source does not parse: %v", err)
+	}
+}
+
+// bkindPath is the import path of the package of bkind.Kind.
+const bkindPath = "github.com/tnnmigga/enum/testdata/gen/b/kind"
+
+// TestGenerateSourceImports tests that generated sources importing named types of other
+// packages, including packages sharing a name, type-check.
+func TestGenerateSourceImports(t *testing.T) {
+	type foreign = struct {
+		Label CodeLabel  `enum:"404:Not Found"`
+		A     akind.Kind `enum:"1"`
+		Group struct {
+			B bkind.Kind `enum:"b"`
+		}
+		All []Entry `enum:"entries"`
+	}
+	tests := []struct {
+		name, pkg string
+		want      []string
+	}{
+		{"by name", "status", []string{"enum.CodeLabel", "kind.Kind", "kind2.Kind",
+			`All: []enum.Entry{
+		{Name: "Label", Value: enum.CodeLabel{
+			Code:  404,
+			Label: "Not Found",
+		}, Tag: "404:Not Found"},
+		{Name: "A", Value: kind.Kind(1), Tag: "1"},
+		{Name: "Group.B", Value: kind2.Kind("b"), Tag: "b"},
+	},`}},
+		// The target's import path tells its own types apart from those of packages that
+		// share its name.
+		{"by path", bkindPath, []string{"enum.CodeLabel", "kind2.Kind", "B Kind"}},
+		{"named like a dependency", "example.com/kind", []string{"kind2.Kind", "kind3.Kind"}},
+	}
+	// A single importer caches the packages it type-checks from source.
+	fset := token.NewFileSet()
+	imp := importer.ForCompiler(fset, "source", nil)
+	for _, tt := range tests {
+		src, err := GenerateSource[foreign](tt.pkg, "Status")
+		if err != nil {
+			t.Fatalf("%s: GenerateSource() error = %v", tt.name, err)
+		}
+		for _, want := range tt.want {
+			if !strings.Contains(src, want) {
+				t.Errorf("%s: generated source does not contain %q:\n%s", tt.name, want, src)
+			}
+		}
+
+		file, err := parser.ParseFile(fset, "status.go", src, 0)
+		if err != nil {
+			t.Fatalf("%s: parsing generated code: %v\n%s", tt.name, err, src)
+		}
+		// Check the file as part of the target package, along with its other files.
+		var files []*ast.File
+		if tt.pkg == bkindPath {
+			other, err := parser.ParseFile(fset, "testdata/gen/b/kind/kind.go", nil, 0)
+			if err != nil {
+				t.Fatal(err)
+			}
+			files = append(files, other)
+		}
+		conf := &types.Config{Importer: imp}
+		if _, err := conf.Check(tt.pkg, fset, append(files, file), nil); err != nil {
+			t.Errorf("%s: type-checking generated code: %v\n%s", tt.name, err, src)
+		}
+	}
+}
+
+// TestGenerateSourceErrors tests that unsupported members and invalid enums are reported.
+func TestGenerateSourceErrors(t *testing.T) {
+	if _, err := GenerateSource[int]("status", "Status"); err == nil {
+		t.Error("GenerateSource[int]() returned nil error")
+	}
+	_, err := GenerateSource[struct {
+		Group struct {
+			Codes []int
+		}
+	}]("status", "Status", WithFieldFilter(func(path string, _ reflect.StructField) bool {
+		return path != "Group.Codes"
+	}))
+	if err == nil || !strings.Contains(err.Error(), "field Group.Codes") {
+		t.Errorf("GenerateSource() error = %v; want error naming Group.Codes", err)
+	}
+}
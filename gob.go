@@ -0,0 +1,90 @@
+package enum
+
+import (
+	"bytes"
+	"encoding/gob"
+	"fmt"
+	"reflect"
+	"sync"
+)
+
+// gobEnums maps the fingerprints of the enums given to RegisterGob, and of their groups,
+// to the enum instances, for decoding members.
+var gobEnums sync.Map
+
+// RegisterGob makes enum and each of its groups available for decoding gob-encoded
+// Members, and registers the named types of its members with gob.Register so that
+// member values can also travel inside interfaces. Members resolve against the enum by
+// Fingerprint, so a member encoded against a different version of the enum fails to
+// decode. Returns an error if enum is not a struct.
+func RegisterGob(enum any) error {
+	enumVal, ok := structValue(enum)
+	if !ok {
+		return fmt.Errorf("type %T is not a struct", enum)
+	}
+	registerGob(enumVal)
+	return nil
+}
+
+// registerGob registers the struct val and recurses into its groups.
+func registerGob(val reflect.Value) {
+	gobEnums.Store(Fingerprint(val.Interface()), val.Interface())
+	typ := val.Type()
+	for i := 0; i < typ.NumField(); i++ {
+		field := typ.Field(i)
+		if !field.IsExported() || isSentinel(field, "enum") {
+			continue
+		}
+		switch {
+		case isGroup(field.Type):
+			registerGob(val.Field(i))
+		case field.Type.PkgPath() != "":
+			gob.Register(val.Field(i).Interface())
+		}
+	}
+}
+
+// gobMember is the gob encoding of a Member: its name and the fingerprint of its enum.
+type gobMember struct {
+	Name        string
+	Fingerprint string
+}
+
+// GobEncode implements gob.GobEncoder, encoding the name of the member and the
+// Fingerprint of its enum. Returns an error if m is invalid.
+func (m Member[V]) GobEncode() ([]byte, error) {
+	if !m.valid {
+		return nil, fmt.Errorf("cannot gob-encode invalid member with value %v", m.value)
+	}
+	var buf bytes.Buffer
+	if err := gob.NewEncoder(&buf).Encode(gobMember{Name: m.name, Fingerprint: m.enum}); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// GobDecode implements gob.GobDecoder, resolving the member by name in the enum
+// registered with RegisterGob under the encoded fingerprint. Returns an error if no such
+// enum is registered, which is the case when the enum has changed since encoding, or if
+// the enum has no member of type V with the encoded name.
+func (m *Member[V]) GobDecode(data []byte) error {
+	var g gobMember
+	if err := gob.NewDecoder(bytes.NewReader(data)).Decode(&g); err != nil {
+		return err
+	}
+	enum, ok := gobEnums.Load(g.Fingerprint)
+	if !ok {
+		return fmt.Errorf("member %s: no enum with fingerprint %s is registered with RegisterGob; the enum may have changed", g.Name, g.Fingerprint)
+	}
+	enumVal, _ := structValue(enum)
+	leaf, ok := findLeaf(enumVal, g.Name, "enum")
+	if !ok {
+		return fmt.Errorf("unknown member %q", g.Name)
+	}
+	value, ok := leaf.value.Interface().(V)
+	if !ok {
+		return fmt.Errorf("member %s has type %s, not %s", g.Name, leaf.field.Type, reflect.TypeOf((*V)(nil)).Elem())
+	}
+	*m = Member[V]{name: g.Name, value: value, valid: true, enum: g.Fingerprint}
+	return nil
+}
@@ -0,0 +1,75 @@
+package enum
+
+import (
+	"bytes"
+	"encoding/gob"
+	"strings"
+	"testing"
+)
+
+// gobRoundTrip encodes in with gob and decodes the result into out.
+func gobRoundTrip(in, out any) error {
+	var buf bytes.Buffer
+	if err := gob.NewEncoder(&buf).Encode(in); err != nil {
+		return err
+	}
+	return gob.NewDecoder(&buf).Decode(out)
+}
+
+// TestMemberGob tests that members of an enum and of its groups round-trip through gob.
+func TestMemberGob(t *testing.T) {
+	status := New[MemberStatus]()
+	if err := RegisterGob(status); err != nil {
+		t.Fatalf("RegisterGob() error = %v", err)
+	}
+
+	type snapshot struct {
+		Code Member[int]
+		Type Member[string]
+	}
+	in := snapshot{MemberOf(status.Code, 404), MemberOf(status, "StatusOK")}
+	var out snapshot
+	if err := gobRoundTrip(in, &out); err != nil {
+		t.Fatalf("gob round trip error = %v", err)
+	}
+	if out != in {
+		t.Errorf("gob round trip = %+v; want %+v", out, in)
+	}
+
+	if err := gobRoundTrip(MemberOf(status.Code, 405), new(Member[int])); err == nil {
+		t.Errorf("encoding an invalid member: error = nil; want an error")
+	}
+	if err := RegisterGob(1); err == nil {
+		t.Errorf("RegisterGob(1) error = nil; want an error")
+	}
+}
+
+// TestMemberGobChangedEnum tests that decoding fails against a changed enum or an
+// unknown member name.
+func TestMemberGobChangedEnum(t *testing.T) {
+	before := New[struct {
+		StatusCreated  int `enum:"201"`
+		StatusNotFound int `enum:"404"`
+	}]()
+	after := New[struct {
+		StatusCreated  int `enum:"201"`
+		StatusNotFound int `enum:"410"`
+	}]()
+	if err := RegisterGob(after); err != nil {
+		t.Fatalf("RegisterGob() error = %v", err)
+	}
+
+	err := gobRoundTrip(MemberOf(before, 404), new(Member[int]))
+	if err == nil || !strings.Contains(err.Error(), "the enum may have changed") {
+		t.Errorf("decoding against a changed enum: error = %v", err)
+	}
+
+	var buf bytes.Buffer
+	if err := gob.NewEncoder(&buf).Encode(gobMember{Name: "StatusGone", Fingerprint: Fingerprint(after)}); err != nil {
+		t.Fatal(err)
+	}
+	var m Member[int]
+	if err := m.GobDecode(buf.Bytes()); err == nil || !strings.Contains(err.Error(), `unknown member "StatusGone"`) {
+		t.Errorf("decoding an unknown member: error = %v", err)
+	}
+}
@@ -0,0 +1,81 @@
+package enum
+
+import "fmt"
+
+// Enum is a typed handle to an enum instance whose methods are backed by metadata
+// computed once by Wrap, instead of reflecting on every call like the free functions.
+// Members are leaves named by their dotted path, e.g. "Code.StatusOK"; for flat enums
+// the results match Keys, Contains, and friends. An Enum is immutable, safe for
+// concurrent use, and cheap to copy.
+type Enum[T any] struct {
+	enum T
+	idx  *index
+}
+
+// Wrap returns a handle to the enum instance e. Lookup options such as WithIgnoreCase
+// configure the handle's methods. Panics if T is not a struct.
+func Wrap[T any](e T, opts ...Option) Enum[T] {
+	enumVal, ok := structValue(e)
+	if !ok {
+		panic(fmt.Sprintf("type %T is not a struct", e))
+	}
+	return Enum[T]{enum: e, idx: newIndex(enumVal, opts...)}
+}
+
+// Of initializes an enum of type T with New and returns a handle to it.
+// The options are passed to both New and Wrap.
+func Of[T any](opts ...Option) Enum[T] {
+	return Wrap(New[T](opts...), opts...)
+}
+
+// Value returns a copy of the wrapped enum instance.
+func (h Enum[T]) Value() T {
+	return h.enum
+}
+
+// Keys returns the dotted names of the members in declaration order.
+func (h Enum[T]) Keys() []string {
+	return h.idx.names()
+}
+
+// Values returns the values of the members in declaration order.
+func (h Enum[T]) Values() []any {
+	return h.idx.values()
+}
+
+// Entries returns the members in declaration order.
+func (h Enum[T]) Entries() []Entry {
+	return append([]Entry(nil), h.idx.entries...)
+}
+
+// Contains reports whether the enum has a member with the given dotted name.
+func (h Enum[T]) Contains(name string) bool {
+	_, err := h.idx.lookup(name)
+	return err == nil
+}
+
+// Parse returns the value of the member with the given dotted name.
+func (h Enum[T]) Parse(name string) (any, error) {
+	return h.idx.parse(name)
+}
+
+// NameOf returns the dotted name of the first member whose value equals v.
+// v must have the member's exact type. Values that cannot be map keys, such as slices,
+// are compared with reflect.DeepEqual.
+func (h Enum[T]) NameOf(v any) (string, bool) {
+	i, ok := h.idx.nameOf(v)
+	if !ok {
+		return "", false
+	}
+	return h.idx.entries[i].Name, true
+}
+
+// String returns the Format listing of the wrapped enum.
+func (h Enum[T]) String() string {
+	return Format(h.enum)
+}
+
+// GoString returns the Format listing of the wrapped enum inside an enum.Enum[T] frame.
+func (h Enum[T]) GoString() string {
+	return goString(fmt.Sprintf("enum.Enum[%T]", h.enum), h.enum)
+}
@@ -0,0 +1,116 @@
+package enum
+
+import (
+	"reflect"
+	"testing"
+)
+
+// TestEnumMatchesFreeFunctions tests that handle methods agree with the free functions.
+func TestEnumMatchesFreeFunctions(t *testing.T) {
+	HttpStatus := New[struct {
+		StatusOK       int `enum:"200"`
+		StatusNotFound int `enum:"404"`
+	}]()
+	h := Wrap(HttpStatus)
+
+	if got, want := h.Keys(), Keys(HttpStatus); !reflect.DeepEqual(got, want) {
+		t.Errorf("Keys() = %v; want %v", got, want)
+	}
+	var want []any
+	for _, v := range Values[int](HttpStatus) {
+		want = append(want, v)
+	}
+	if got := h.Values(); !reflect.DeepEqual(got, want) {
+		t.Errorf("Values() = %v; want %v", got, want)
+	}
+	if got, want := h.Entries(), Entries(HttpStatus); !reflect.DeepEqual(got, want) {
+		t.Errorf("Entries() = %v; want %v", got, want)
+	}
+	for _, name := range []string{"StatusOK", "Unknown"} {
+		v, err := h.Parse(name)
+		want, wantErr := Parse[int](HttpStatus, name)
+		if (err == nil) != (wantErr == nil) || (err == nil && v != want) {
+			t.Errorf("Parse(%q) = %v, %v; want %v, %v", name, v, err, want, wantErr)
+		}
+		if h.Contains(name) != (wantErr == nil) {
+			t.Errorf("Contains(%q) = %v; want %v", name, h.Contains(name), wantErr == nil)
+		}
+	}
+	if got := h.Value(); got != HttpStatus {
+		t.Errorf("Value() = %+v; want %+v", got, HttpStatus)
+	}
+}
+
+// TestEnumNested tests handle lookups of nested members by dotted name.
+func TestEnumNested(t *testing.T) {
+	h := Of[struct {
+		Code struct {
+			StatusOK int `enum:"200"`
+		}
+		Type struct {
+			StatusOK string
+		}
+	}]()
+
+	if got, want := h.Keys(), []string{"Code.StatusOK", "Type.StatusOK"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("Keys() = %v; want %v", got, want)
+	}
+	if name, ok := h.NameOf(200); !ok || name != "Code.StatusOK" {
+		t.Errorf("NameOf(200) = %q, %v; want %q, true", name, ok, "Code.StatusOK")
+	}
+	if name, ok := h.NameOf("StatusOK"); !ok || name != "Type.StatusOK" {
+		t.Errorf("NameOf(%q) = %q, %v; want %q, true", "StatusOK", name, ok, "Type.StatusOK")
+	}
+	if h.Contains("Code") {
+		t.Errorf("Contains(%q) = true; want false for a group", "Code")
+	}
+}
+
+// TestEnumImmutable tests that results returned by the handle do not alias its state.
+func TestEnumImmutable(t *testing.T) {
+	h := Of[struct{ A, B string }]()
+	h.Keys()[0] = "X"
+	h.Entries()[0].Name = "X"
+	if got := h.Keys()[0]; got != "A" {
+		t.Errorf("Keys()[0] = %q after mutation; want %q", got, "A")
+	}
+}
+
+// TestEnumNoAllocs tests that lookups on the handle do not allocate.
+func TestEnumNoAllocs(t *testing.T) {
+	h := Of[struct {
+		StatusOK       int `enum:"200"`
+		StatusNotFound int `enum:"404"`
+	}]()
+	v, _ := h.Parse("StatusNotFound")
+	allocs := testing.AllocsPerRun(100, func() {
+		h.Contains("StatusOK")
+		h.Parse("StatusOK")
+		h.NameOf(v)
+	})
+	if allocs != 0 {
+		t.Errorf("lookups allocated %v times per run; want 0", allocs)
+	}
+}
+
+// TestEnumUncomparable tests handles over members whose values cannot be map keys.
+func TestEnumUncomparable(t *testing.T) {
+	e := struct {
+		A int
+		B []string
+		C []string
+	}{A: 1, B: []string{"b"}, C: []string{"c"}}
+	h := Wrap(e)
+	if name, ok := h.NameOf([]string{"c"}); !ok || name != "C" {
+		t.Errorf("NameOf([c]) = %q, %v; want C, true", name, ok)
+	}
+	if name, ok := h.NameOf(1); !ok || name != "A" {
+		t.Errorf("NameOf(1) = %q, %v; want A, true", name, ok)
+	}
+	if _, ok := h.NameOf([]string{"d"}); ok {
+		t.Error("NameOf([d]) = true; want false")
+	}
+	if v, ok := Freeze(e).Get("B"); !ok || !reflect.DeepEqual(v, []string{"b"}) {
+		t.Errorf("Freeze().Get(B) = %v, %v; want [b], true", v, ok)
+	}
+}
@@ -0,0 +1,34 @@
+package enum
+
+import (
+	"fmt"
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// identifierPattern matches ASCII Go identifiers.
+var identifierPattern = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+
+// CheckIdentifiers verifies that every string leaf member of enum holds an ASCII Go
+// identifier, matching ^[A-Za-z_][A-Za-z0-9_]*$, as required by enums that feed code
+// generation. The returned error lists every offending member with its value.
+// Returns an error if enum is not a struct.
+func CheckIdentifiers(enum any) error {
+	enumVal, ok := structValue(enum)
+	if !ok {
+		return fmt.Errorf("type %T is not a struct", enum)
+	}
+
+	var invalid []string
+	for _, m := range leaves(enumVal, "enum") {
+		if m.value.Kind() == reflect.String && !identifierPattern.MatchString(m.value.String()) {
+			invalid = append(invalid, m.path+" = "+strconv.Quote(m.value.String()))
+		}
+	}
+	if len(invalid) > 0 {
+		return fmt.Errorf("members are not valid identifiers: %s", strings.Join(invalid, ", "))
+	}
+	return nil
+}
@@ -0,0 +1,34 @@
+package enum
+
+import "testing"
+
+// TestCheckIdentifiers tests that offending string members are all reported.
+func TestCheckIdentifiers(t *testing.T) {
+	valid := New[struct {
+		StatusOK string
+		Code     int `enum:"200"`
+		Group    struct {
+			Snake string `enum:"_snake_case9"`
+		}
+	}]()
+	if err := CheckIdentifiers(valid); err != nil {
+		t.Errorf("CheckIdentifiers() error = %v; want nil", err)
+	}
+
+	invalid := New[struct {
+		Spaced string `enum:"not found"`
+		OK     string
+		Group  struct {
+			Digit  string `enum:"9lives"`
+			Dotted string `enum:"a.b"`
+			Empty  string `enum:"%%"`
+		}
+	}](WithOverrides(map[string]any{"Group.Empty": ""}))
+	want := `members are not valid identifiers: Spaced = "not found", Group.Digit = "9lives", Group.Dotted = "a.b", Group.Empty = ""`
+	if err := CheckIdentifiers(invalid); err == nil || err.Error() != want {
+		t.Errorf("CheckIdentifiers() error = %v; want %s", err, want)
+	}
+	if err := CheckIdentifiers(123); err == nil {
+		t.Error("CheckIdentifiers(123) returned nil error")
+	}
+}
@@ -0,0 +1,154 @@
+package enum
+
+import "reflect"
+
+// Entry describes a leaf member of an enum: its dotted name, its value, and the raw
+// tag it was declared with under the enum tag key, "enum" unless set by WithTagKey
+// (empty if untagged).
+type Entry struct {
+	Name  string `json:"name"`
+	Value any    `json:"value"`
+	Tag   string `json:"tag,omitempty"`
+}
+
+// Entries returns the leaf members of enum, with nested members named by their dotted
+// path, in the order selected by WithOrder, declaration order by default or if the
+// members cannot be ordered by value. Returns nil if enum is not a struct.
+func Entries(enum any, opts ...Option) []Entry {
+	enumVal, ok := structValue(enum)
+	if !ok {
+		return nil
+	}
+
+	o := buildOptions(opts)
+	members, _ := sortedFields(leaves(enumVal, o.tagKey()), opts)
+	var entries []Entry
+	for _, m := range members {
+		entry := entryOf(m, o.tagKey())
+		entry.Name = o.outputName(entry.Name)
+		entries = append(entries, entry)
+	}
+	return entries
+}
+
+// entriesOf returns the entries of the leaf members of the struct value val in
+// declaration order, skipping the sentinels under tagKey.
+func entriesOf(val reflect.Value, tagKey string) []Entry {
+	var entries []Entry
+	for _, m := range leaves(val, tagKey) {
+		entries = append(entries, entryOf(m, tagKey))
+	}
+	return entries
+}
+
+// entryOf returns the entry describing the member m, with its tag under tagKey.
+func entryOf(m member, tagKey string) Entry {
+	return Entry{
+		Name:  m.path,
+		Value: m.value.Interface(),
+		Tag:   m.field.Tag.Get(tagKey),
+	}
+}
+
+// index holds the entries of an enum with lookup tables by name and by value.
+// It is immutable once built and shared by the handle types.
+type index struct {
+	val     reflect.Value
+	entries []Entry
+	byName  map[string]int
+	byValue map[any]int
+
+	// uncomparable lists the positions of the members whose values cannot be map keys
+	// and are therefore missing from byValue.
+	uncomparable []int
+
+	// opts configures name lookups, such as case-insensitive matching and renames.
+	opts Options
+}
+
+// newIndex builds the index of the struct value val, configured by opts.
+func newIndex(val reflect.Value, opts ...Option) *index {
+	o := buildOptions(opts)
+	idx := &index{
+		opts:    o,
+		val:     val,
+		entries: entriesOf(val, o.tagKey()),
+		byName:  make(map[string]int),
+		byValue: make(map[any]int),
+	}
+	for i, e := range idx.entries {
+		idx.byName[e.Name] = i
+		if !isComparable(e.Value) {
+			idx.uncomparable = append(idx.uncomparable, i)
+			continue
+		}
+		// The first member declared with a value wins the reverse lookup.
+		if _, ok := idx.byValue[e.Value]; !ok {
+			idx.byValue[e.Value] = i
+		}
+	}
+	return idx
+}
+
+// lookup returns the position of the member with the given dotted name, resolving
+// the external names given by WithRename and, if enabled, case-insensitive matches.
+func (idx *index) lookup(name string) (int, error) {
+	if i, ok := idx.byName[name]; ok {
+		return i, nil
+	}
+	// Fall back to resolving aliases and case-insensitive names by reflection.
+	m, err := resolveLeaf(idx.val, name, &idx.opts)
+	if err != nil {
+		return 0, err
+	}
+	return idx.byName[m.path], nil
+}
+
+// nameOf returns the position of the first member whose value equals v. Members whose
+// values cannot be map keys are compared by reflect.DeepEqual instead.
+func (idx *index) nameOf(v any) (int, bool) {
+	if isComparable(v) {
+		if i, ok := idx.byValue[v]; ok {
+			return i, true
+		}
+	}
+	for _, i := range idx.uncomparable {
+		if reflect.DeepEqual(idx.entries[i].Value, v) {
+			return i, true
+		}
+	}
+	return 0, false
+}
+
+// isComparable reports whether v can be used as a map key.
+func isComparable(v any) bool {
+	t := reflect.TypeOf(v)
+	return t == nil || t.Comparable()
+}
+
+// parse returns the value of the named member or a descriptive error.
+func (idx *index) parse(name string) (any, error) {
+	i, err := idx.lookup(name)
+	if err != nil {
+		return nil, err
+	}
+	return idx.entries[i].Value, nil
+}
+
+// names returns the member names in declaration order.
+func (idx *index) names() []string {
+	names := make([]string, len(idx.entries))
+	for i, e := range idx.entries {
+		names[i] = e.Name
+	}
+	return names
+}
+
+// values returns the member values in declaration order.
+func (idx *index) values() []any {
+	values := make([]any, len(idx.entries))
+	for i, e := range idx.entries {
+		values[i] = e.Value
+	}
+	return values
+}
@@ -0,0 +1,42 @@
+package enum
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+)
+
+// TestEntries tests the names, values, and tags of nested entries.
+func TestEntries(t *testing.T) {
+	HttpStatus := New[struct {
+		Code struct {
+			StatusOK int `enum:"200"`
+		}
+		Default string
+	}]()
+
+	got := Entries(HttpStatus)
+	want := []Entry{
+		{Name: "Code.StatusOK", Value: 200, Tag: "200"},
+		{Name: "Default", Value: "Default"},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Entries(%v) = %v; want %v", HttpStatus, got, want)
+	}
+	if got := Entries(123); got != nil {
+		t.Errorf("Entries(123) = %v; want nil", got)
+	}
+}
+
+// TestEntriesJSONMixedKinds tests that entries encode each kind as the matching JSON type.
+func TestEntriesJSONMixedKinds(t *testing.T) {
+	data, err := json.Marshal(Entries(mixedKinds))
+	if err != nil {
+		t.Fatalf("json.Marshal() error = %v", err)
+	}
+	want := `[{"name":"Enabled","value":true},{"name":"Retries","value":-3},` +
+		`{"name":"Capacity","value":512},{"name":"Ratio","value":0.25},{"name":"Name","value":"primary"}]`
+	if string(data) != want {
+		t.Errorf("json.Marshal(Entries()) = %s; want %s", data, want)
+	}
+}
@@ -0,0 +1,193 @@
+package tagrule
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Group holds the options of a nested group's tag, such as "default=unknown" or
+// "prefix=evt.,tagged".
+type Group struct {
+	// Default is the tag of the group's untagged members if HasDefault is set.
+	// Otherwise, untagged members copy the member of the sibling group Same, if set.
+	Default    string
+	HasDefault bool
+	Same       string
+
+	// Prefix is prepended to the group's untagged string members if HasPrefix is set,
+	// and to its tagged ones too if Tagged is set.
+	Prefix    string
+	HasPrefix bool
+	Tagged    bool
+
+	// Offset is added to the group's integer members, tagged ones included unless
+	// Absolute is set.
+	Offset   int64
+	Absolute bool
+
+	// Base replaces the start index of the group's untagged integer members if HasBase
+	// is set.
+	Base    int64
+	HasBase bool
+}
+
+// ParseGroup parses the options of a nested group's tag. A tag with a segment that is
+// neither "key=value" nor a known flag, such as "group1", holds no options and yields
+// the zero Group, as group tags used to be ignored. Returns an error for unknown keys
+// and invalid values.
+func ParseGroup(tag string) (Group, error) {
+	var group Group
+	if tag == "" {
+		return group, nil
+	}
+	for _, segment := range Split(tag) {
+		segment = strings.TrimSpace(segment)
+		if !strings.Contains(segment, "=") && segment != "tagged" && segment != "absolute" {
+			return group, nil
+		}
+	}
+	for _, segment := range Split(tag) {
+		segment = strings.TrimSpace(segment)
+		key, value, _ := strings.Cut(segment, "=")
+		switch key {
+		case "default":
+			group.Default = Unescape(value)
+			group.HasDefault = true
+		case "same":
+			if value == "" {
+				return group, fmt.Errorf("invalid option %q: missing group", segment)
+			}
+			group.Same = value
+		case "prefix":
+			group.Prefix = Unescape(value)
+			group.HasPrefix = true
+		case "tagged":
+			group.Tagged = true
+		case "offset":
+			n, err := strconv.ParseInt(value, 10, 64)
+			if err != nil {
+				return group, fmt.Errorf("invalid option %q: %v", segment, err)
+			}
+			group.Offset = n
+		case "absolute":
+			group.Absolute = true
+		case "base":
+			n, err := strconv.ParseInt(value, 10, 64)
+			if err != nil {
+				return group, fmt.Errorf("invalid option %q: %v", segment, err)
+			}
+			group.Base, group.HasBase = n, true
+		default:
+			return group, fmt.Errorf("unknown option %q", key)
+		}
+	}
+	if group.HasDefault && group.Same != "" {
+		return group, fmt.Errorf("options %q and %q are exclusive", "default", "same")
+	}
+	if group.Tagged && !group.HasPrefix {
+		return group, fmt.Errorf("option %q requires a prefix", "tagged")
+	}
+	return group, nil
+}
+
+// Cases are the names accepted by the "case" marker option.
+var Cases = []string{"asis", "snake", "screaming_snake", "kebab", "camel", "lower", "upper"}
+
+// Marker holds the options of a blank marker field's tag, such as
+// "start=100,step=10,case=snake,strict". Each Has field reports whether the tag sets
+// the option next to it.
+type Marker struct {
+	Start, Step       int64
+	HasStart, HasStep bool
+	Case              string
+	HasCase           bool
+	Strict, HasStrict bool
+}
+
+// ParseMarker parses the options of a marker field's tag. When an option repeats, the
+// last one wins. Returns an error for unknown keys and invalid values.
+func ParseMarker(tag string) (Marker, error) {
+	var marker Marker
+	for _, segment := range Split(tag) {
+		key, value, hasValue := strings.Cut(strings.TrimSpace(segment), "=")
+		value = Unescape(value)
+		switch key {
+		case "start", "step":
+			n, err := strconv.ParseInt(value, 10, 64)
+			if err != nil {
+				return marker, fmt.Errorf("invalid option %q: %v", segment, err)
+			}
+			if key == "start" {
+				marker.Start, marker.HasStart = n, true
+			} else {
+				marker.Step, marker.HasStep = n, true
+			}
+		case "case":
+			if !isCase(value) {
+				return marker, fmt.Errorf("invalid option %q: unknown case %q", segment, value)
+			}
+			marker.Case, marker.HasCase = value, true
+		case "strict":
+			strict := true
+			if hasValue {
+				var err error
+				if strict, err = strconv.ParseBool(value); err != nil {
+					return marker, fmt.Errorf("invalid option %q: %v", segment, err)
+				}
+			}
+			marker.Strict, marker.HasStrict = strict, true
+		case "":
+			// Allow empty segments, e.g. from a trailing comma.
+		default:
+			return marker, fmt.Errorf("unknown option %q", key)
+		}
+	}
+	return marker, nil
+}
+
+// isCase reports whether name is one of Cases.
+func isCase(name string) bool {
+	for _, c := range Cases {
+		if c == name {
+			return true
+		}
+	}
+	return false
+}
+
+// ParseCodeLabel splits a code-label member's "code:label" tag at the first colon.
+func ParseCodeLabel(tag string) (int64, string, error) {
+	codeStr, label, ok := strings.Cut(tag, ":")
+	if !ok {
+		return 0, "", fmt.Errorf("%q is not of the form code:label", tag)
+	}
+	code, err := strconv.ParseInt(codeStr, 10, 64)
+	if err != nil {
+		return 0, "", err
+	}
+	return code, label, nil
+}
+
+// ParseRange parses a range member's "lo-hi" tag. The separating dash is the first one
+// after the first character, so that a negative lower bound keeps its sign. Returns an
+// error if the bounds do not parse or lo > hi.
+func ParseRange(tag string) (lo, hi int64, err error) {
+	i := -1
+	if tag != "" {
+		i = strings.Index(tag[1:], "-")
+	}
+	if i < 0 {
+		return 0, 0, fmt.Errorf("%q is not of the form lo-hi", tag)
+	}
+	if lo, err = strconv.ParseInt(tag[:i+1], 10, 64); err != nil {
+		return 0, 0, err
+	}
+	if hi, err = strconv.ParseInt(tag[i+2:], 10, 64); err != nil {
+		return 0, 0, err
+	}
+	if lo > hi {
+		return 0, 0, fmt.Errorf("range %q is inverted: %d > %d", tag, lo, hi)
+	}
+	return lo, hi, nil
+}
@@ -0,0 +1,173 @@
+// Package tagrule holds the rules for enum tags shared by package enum, which applies
+// them when initializing enums, and package enumcheck, which checks them statically.
+package tagrule
+
+import (
+	"fmt"
+	"math"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// Option is a "key=value" option following the value in a leaf tag.
+type Option struct {
+	Key, Value string
+}
+
+// Parse splits a leaf tag of the form "value,key=value,..." into its value and
+// options. Segments are separated by commas; a comma preceded by a backslash is kept
+// literally. Only segments whose key is a known option are options; any other segment
+// belongs to the value, so tags such as "Hello, world" keep their commas.
+func Parse(tag string) (string, []Option, error) {
+	if !strings.Contains(tag, ",") {
+		return tag, nil, nil
+	}
+
+	var values []string
+	var options []Option
+	for i, segment := range Split(tag) {
+		key, value, ok := strings.Cut(segment, "=")
+		if i == 0 || !ok || !isTagOption(key) {
+			values = append(values, segment)
+			continue
+		}
+		if err := checkTagOption(key, value); err != nil {
+			return "", nil, err
+		}
+		options = append(options, Option{Key: key, Value: Unescape(value)})
+	}
+	return Unescape(strings.Join(values, ",")), options, nil
+}
+
+// Split splits tag at commas not preceded by a backslash, keeping escapes intact.
+func Split(tag string) []string {
+	var segments []string
+	start := 0
+	for i := 0; i < len(tag); i++ {
+		switch tag[i] {
+		case '\\':
+			i++
+		case ',':
+			segments = append(segments, tag[start:i])
+			start = i + 1
+		}
+	}
+	return append(segments, tag[start:])
+}
+
+// Unescape replaces the escaped commas of a tag segment with commas.
+func Unescape(s string) string {
+	return strings.ReplaceAll(s, `\,`, ",")
+}
+
+// isTagOption reports whether key names a known tag option.
+func isTagOption(key string) bool {
+	switch key {
+	case "display", "weight", "desc":
+		return true
+	}
+	return strings.HasPrefix(key, "i18n.")
+}
+
+// checkTagOption validates a known tag option.
+func checkTagOption(key, value string) error {
+	switch {
+	case key == "i18n.":
+		return fmt.Errorf("invalid option %q: missing locale", key)
+	case key == "weight":
+		weight, err := strconv.ParseFloat(value, 64)
+		switch {
+		case err != nil:
+			return fmt.Errorf("invalid option %q: %v", key+"="+value, err)
+		case math.IsNaN(weight) || math.IsInf(weight, 0):
+			return fmt.Errorf("invalid option %q: weight is not finite", key+"="+value)
+		case weight < 0:
+			return fmt.Errorf("invalid option %q: negative weight", key+"="+value)
+		}
+	}
+	return nil
+}
+
+// IsReference reports whether a leaf tag value refers to a member of a registered enum,
+// in the form "@name:Member". Values such as "@everyone" without a colon, and values
+// escaped as described by Literal, are not references.
+func IsReference(value string) bool {
+	return strings.HasPrefix(value, "@") && strings.Contains(value, ":")
+}
+
+// Mirror returns the group named by a leaf tag value of the form "same=Group", which
+// takes the value of the member of the same name in Group. ok is false for any other
+// value, including one escaped as described by Literal.
+func Mirror(value string) (group string, ok bool) {
+	if !strings.HasPrefix(value, "same=") {
+		return "", false
+	}
+	return value[len("same="):], true
+}
+
+// Literal removes the backslash escaping a leaf tag value that would otherwise be read
+// as a reference or a mirror, so that `\@user:name` is the literal value "@user:name"
+// and `\same=Code` the literal value "same=Code".
+func Literal(value string) string {
+	if strings.HasPrefix(value, `\@`) || strings.HasPrefix(value, `\same=`) {
+		return value[1:]
+	}
+	return value
+}
+
+// ParseInt parses the value of a signed integer member's tag, before range checks.
+func ParseInt(tag string) (int64, error) {
+	return strconv.ParseInt(tag, 10, 64)
+}
+
+// ParseUint parses the value of an unsigned integer member's tag, before range checks.
+func ParseUint(tag string) (uint64, error) {
+	return strconv.ParseUint(tag, 10, 64)
+}
+
+// CheckInt verifies that value fits within the range of the signed integer kind.
+// Since rune is an alias of int32, reflection cannot tell them apart, so int32 messages
+// mention both names.
+func CheckInt(value int64, kind reflect.Kind) error {
+	switch kind {
+	case reflect.Int8:
+		if value < -1<<7 || value > 1<<7-1 {
+			return fmt.Errorf("value %d overflows int8 range [-128, 127]", value)
+		}
+	case reflect.Int16:
+		if value < -1<<15 || value > 1<<15-1 {
+			return fmt.Errorf("value %d overflows int16 range [-32768, 32767]", value)
+		}
+	case reflect.Int32:
+		if value < -1<<31 || value > 1<<31-1 {
+			return fmt.Errorf("value %d overflows int32 (rune) range [-2147483648, 2147483647]", value)
+		}
+	case reflect.Int, reflect.Int64:
+		// No additional check needed for int/int64, as value is already int64.
+	}
+	return nil
+}
+
+// CheckUint verifies that value fits within the range of the unsigned integer kind.
+// Since byte is an alias of uint8, reflection cannot tell them apart, so uint8 messages
+// mention both names.
+func CheckUint(value uint64, kind reflect.Kind) error {
+	switch kind {
+	case reflect.Uint8:
+		if value > 1<<8-1 {
+			return fmt.Errorf("value %d overflows uint8 (byte) range [0, 255]", value)
+		}
+	case reflect.Uint16:
+		if value > 1<<16-1 {
+			return fmt.Errorf("value %d overflows uint16 range [0, 65535]", value)
+		}
+	case reflect.Uint32:
+		if value > 1<<32-1 {
+			return fmt.Errorf("value %d overflows uint32 range [0, 4294967295]", value)
+		}
+	case reflect.Uint, reflect.Uint64:
+		// No additional check needed for uint/uint64, as value is already uint64.
+	}
+	return nil
+}
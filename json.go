@@ -0,0 +1,71 @@
+package enum
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strconv"
+)
+
+// NewFromJSON initializes an enum instance of type T like TryNew, then overrides members
+// with the values of the JSON object data. Keys name members by dotted path, and nested
+// objects name the members of nested groups, so {"Code": {"StatusOK": 299}} and
+// {"Code.StatusOK": 299} are equivalent. Members not mentioned keep their tagged or
+// default value. JSON numbers are converted to the member's integer kind with the usual
+// overflow checks. The overrides take precedence over any given with WithOverrides.
+// Returns an error if data is not a JSON object, names an unknown member, or holds a
+// value that does not fit its member.
+func NewFromJSON[T any](data []byte, opts ...Option) (T, error) {
+	var zero T
+	var object map[string]any
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	if err := decoder.Decode(&object); err != nil {
+		return zero, fmt.Errorf("invalid JSON overrides: %v", err)
+	}
+
+	o := buildOptions(opts)
+	overrides := make(map[string]any, len(o.Overrides)+len(object))
+	for path, value := range o.Overrides {
+		overrides[path] = value
+	}
+	if err := flattenJSON(&o, "", object, overrides); err != nil {
+		return zero, err
+	}
+	o.Overrides = overrides
+	return NewWith[T](o)
+}
+
+// flattenJSON stores the values of object into overrides keyed by member path below
+// prefix, descending into nested objects and converting numbers to integers.
+func flattenJSON(o *Options, prefix string, object map[string]any, overrides map[string]any) error {
+	for key, value := range object {
+		path := o.join(prefix, key)
+		switch v := value.(type) {
+		case map[string]any:
+			if err := flattenJSON(o, path, v, overrides); err != nil {
+				return err
+			}
+		case json.Number:
+			n, err := jsonInteger(v)
+			if err != nil {
+				return fmt.Errorf("field %s: %v", path, err)
+			}
+			overrides[path] = n
+		default:
+			overrides[path] = value
+		}
+	}
+	return nil
+}
+
+// jsonInteger converts a JSON number to an int64, or a uint64 if it is too large.
+func jsonInteger(n json.Number) (any, error) {
+	if i, err := strconv.ParseInt(n.String(), 10, 64); err == nil {
+		return i, nil
+	}
+	if u, err := strconv.ParseUint(n.String(), 10, 64); err == nil {
+		return u, nil
+	}
+	return nil, fmt.Errorf("%s is not an integer in range", n)
+}
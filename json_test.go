@@ -0,0 +1,52 @@
+package enum
+
+import (
+	"strings"
+	"testing"
+)
+
+// JSONStatus is the enum overridden by the JSON tests.
+type JSONStatus struct {
+	Name string
+	Code struct {
+		StatusOK       int   `enum:"200"`
+		StatusNotFound int   `enum:"404"`
+		Retries        uint8 `enum:"3"`
+	}
+}
+
+// TestNewFromJSON tests overriding members from nested and dotted JSON keys.
+func TestNewFromJSON(t *testing.T) {
+	got, err := NewFromJSON[JSONStatus]([]byte(`{"Name": "custom", "Code": {"StatusOK": 299}, "Code.Retries": 255}`))
+	if err != nil {
+		t.Fatalf("NewFromJSON() error = %v", err)
+	}
+	if got.Name != "custom" || got.Code.StatusOK != 299 || got.Code.StatusNotFound != 404 || got.Code.Retries != 255 {
+		t.Errorf("NewFromJSON() = %+v; want overrides applied and defaults kept", got)
+	}
+
+	got, err = NewFromJSON[JSONStatus]([]byte(`{"Code": {"StatusOK": 201}}`), WithOverrides(map[string]any{"Code.StatusOK": 1, "Code.StatusNotFound": 2}))
+	if err != nil || got.Code.StatusOK != 201 || got.Code.StatusNotFound != 2 {
+		t.Errorf("NewFromJSON() = %+v, %v; want JSON to win over WithOverrides", got, err)
+	}
+}
+
+// TestNewFromJSONErrors tests invalid JSON, unknown members, and out-of-range numbers.
+func TestNewFromJSONErrors(t *testing.T) {
+	tests := []struct {
+		data string
+		want string
+	}{
+		{`[1, 2]`, "invalid JSON"},
+		{`{"Code": {"Missing": 1}}`, "Code.Missing"},
+		{`{"Code": {"Retries": 256}}`, "overflows"},
+		{`{"Code": {"Retries": -1}}`, "Code.Retries"},
+		{`{"Code": {"StatusOK": 1.5}}`, "not an integer"},
+		{`{"Code": {"StatusOK": "200"}}`, "cannot assign string"},
+	}
+	for _, tt := range tests {
+		if _, err := NewFromJSON[JSONStatus]([]byte(tt.data)); err == nil || !strings.Contains(err.Error(), tt.want) {
+			t.Errorf("NewFromJSON(%s) error = %v; want error containing %q", tt.data, err, tt.want)
+		}
+	}
+}
@@ -0,0 +1,118 @@
+package enum
+
+import (
+	"fmt"
+	"reflect"
+	"sync"
+)
+
+// Member is a value of type V together with the name of the enum member holding it,
+// to pass around and log instead of a naked value. It is comparable, and so usable as
+// a map key, with == comparing the name, the value, and the enum holding the member, so
+// that members of different enums are distinct. A Member built from a value that no
+// member holds is invalid rather than a panic, so it can still flow through logging.
+type Member[V comparable] struct {
+	name  string
+	value V
+	valid bool
+	enum  string // Fingerprint of the enum holding the member, for gob
+}
+
+// memberFingerprints caches the Fingerprint of the last enum of each type given to
+// MemberOf, keyed by reflect.Type, so that it holds one entry per enum type however
+// many enum values pass through.
+var memberFingerprints sync.Map
+
+// fingerprintEntry is a cached Fingerprint together with the enum it belongs to.
+type fingerprintEntry struct {
+	enum any
+	fp   string
+}
+
+// MemberOf returns the first leaf member of enum of type V holding v, such as
+// MemberOf(HttpStatus.Code, 404), or an invalid Member holding v if there is none or
+// enum is not a struct.
+func MemberOf[V comparable](enum any, v V) Member[V] {
+	c, err := CheckValue(enum, v)
+	if err != nil {
+		return Member[V]{value: v}
+	}
+	return Member[V]{name: c.name, value: v, valid: true, enum: cachedFingerprint(enum)}
+}
+
+// cachedFingerprint returns the Fingerprint of enum, reusing the one computed for the
+// last enum of the same type if it is equal to enum. Enums that cannot be compared are
+// fingerprinted on every call.
+func cachedFingerprint(enum any) string {
+	if !isComparable(enum) {
+		return Fingerprint(enum)
+	}
+	typ := reflect.TypeOf(enum)
+	if e, ok := memberFingerprints.Load(typ); ok && e.(*fingerprintEntry).enum == enum {
+		return e.(*fingerprintEntry).fp
+	}
+	fp := Fingerprint(enum)
+	memberFingerprints.Store(typ, &fingerprintEntry{enum: enum, fp: fp})
+	return fp
+}
+
+// Name returns the dotted name of the member, or "" if m is invalid.
+func (m Member[V]) Name() string {
+	return m.name
+}
+
+// Value returns the value m was built from.
+func (m Member[V]) Value() V {
+	return m.value
+}
+
+// IsValid reports whether a member of the enum holds the value.
+func (m Member[V]) IsValid() bool {
+	return m.valid
+}
+
+// String returns the name followed by the value in parentheses, such as
+// "StatusNotFound(404)", or "<invalid>(405)" if m is invalid.
+func (m Member[V]) String() string {
+	name := m.name
+	if !m.valid {
+		name = "<invalid>"
+	}
+	return fmt.Sprintf("%s(%v)", name, m.value)
+}
+
+// Handle is a typed reference to a single enum member, resolved once by HandleOf and
+// then passed around instead of looking the member up by name again.
+type Handle[V comparable] struct {
+	name  string
+	value V
+}
+
+// HandleOf returns a Handle to the leaf member of enum named by name, which may be a
+// dotted path. It accepts the same options as Parse and fails in the same cases.
+func HandleOf[V comparable](enum any, name string, opts ...Option) (Handle[V], error) {
+	value, err := Parse[V](enum, name, opts...)
+	if err != nil {
+		return Handle[V]{}, err
+	}
+	// Resolve the declared name, which differs from name with WithIgnoreCase.
+	enumVal, _ := structValue(enum)
+	o := buildOptions(opts)
+	m, _ := resolveLeaf(enumVal, name, &o)
+	return Handle[V]{name: m.path, value: value}, nil
+}
+
+// Name returns the dotted name of the member.
+func (h Handle[V]) Name() string {
+	return h.name
+}
+
+// Value returns the value of the member.
+func (h Handle[V]) Value() V {
+	return h.value
+}
+
+// Is reports whether v is the value of the member.
+func (h Handle[V]) Is(v V) bool {
+	return h.value == v
+}
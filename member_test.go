@@ -0,0 +1,113 @@
+package enum
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+)
+
+// MemberStatus is the enum of the Member tests.
+type MemberStatus struct {
+	Code struct {
+		StatusOK       int `enum:"200"`
+		StatusNotFound int `enum:"404"`
+	}
+	Type struct {
+		StatusOK string
+	}
+}
+
+// TestMemberOf tests valid and invalid members and their String output.
+func TestMemberOf(t *testing.T) {
+	status := New[MemberStatus]()
+
+	m := MemberOf(status.Code, 404)
+	if !m.IsValid() || m.Name() != "StatusNotFound" || m.Value() != 404 || m.String() != "StatusNotFound(404)" {
+		t.Errorf("MemberOf(Code, 404) = %v; want valid StatusNotFound(404)", m)
+	}
+	if m := MemberOf(status, "StatusOK"); !m.IsValid() || m.String() != "Type.StatusOK(StatusOK)" {
+		t.Errorf("MemberOf(status, StatusOK) = %v; want Type.StatusOK(StatusOK)", m)
+	}
+
+	invalid := MemberOf(status.Code, 405)
+	if invalid.IsValid() || invalid.Name() != "" || invalid.Value() != 405 || invalid.String() != "<invalid>(405)" {
+		t.Errorf("MemberOf(Code, 405) = %v; want invalid <invalid>(405)", invalid)
+	}
+	if got := fmt.Sprint(MemberOf(42, 1)); got != "<invalid>(1)" {
+		t.Errorf("MemberOf(42, 1) = %s; want <invalid>(1)", got)
+	}
+}
+
+// TestMemberMapKey tests that members are comparable and usable as map keys.
+func TestMemberMapKey(t *testing.T) {
+	status := New[MemberStatus]()
+	hits := map[Member[int]]int{}
+	for _, code := range []int{200, 404, 404, 405} {
+		hits[MemberOf(status.Code, code)]++
+	}
+	if hits[MemberOf(status.Code, 404)] != 2 || hits[MemberOf(status.Code, 200)] != 1 || hits[MemberOf(status.Code, 405)] != 1 {
+		t.Errorf("hits = %v; want StatusOK 1, StatusNotFound 2, invalid 1", hits)
+	}
+	if MemberOf(status.Code, 200) == MemberOf(status.Code, 404) {
+		t.Error("members with different values compare equal")
+	}
+
+	// Members of different enums differ even with the same name and value.
+	other := New[struct {
+		StatusOK       int `enum:"200"`
+		StatusNotFound int `enum:"404"`
+		StatusTeapot   int `enum:"418"`
+	}]()
+	if MemberOf(other, 404) == MemberOf(status.Code, 404) {
+		t.Error("members of different enums compare equal")
+	}
+}
+
+// TestMemberFingerprintCache tests that members of equal-typed enums with different
+// values carry their own enum's fingerprint, and that the cache keeps one entry per type.
+func TestMemberFingerprintCache(t *testing.T) {
+	type Codes struct {
+		Ok   string
+		Fail string
+	}
+	snake := New[Codes](WithNamingStyle(NamingSnake))
+	upper := New[Codes](WithNamingStyle(NamingUpper))
+	for _, enum := range []Codes{snake, upper, snake} {
+		if m := MemberOf(enum, enum.Ok); m.enum != Fingerprint(enum) {
+			t.Errorf("MemberOf(%v).enum = %s; want %s", enum, m.enum, Fingerprint(enum))
+		}
+	}
+	if MemberOf(snake, snake.Ok) == MemberOf(upper, upper.Ok) {
+		t.Error("members of different enums compare equal")
+	}
+
+	entries := 0
+	memberFingerprints.Range(func(key, _ any) bool {
+		if key == reflect.TypeOf(Codes{}) {
+			entries++
+		}
+		return true
+	})
+	if entries != 1 {
+		t.Errorf("cache holds %d entries for the type; want 1", entries)
+	}
+}
+
+// TestHandleOf tests resolving, comparing, and failing to resolve member handles.
+func TestHandleOf(t *testing.T) {
+	status := New[MemberStatus]()
+
+	h, err := HandleOf[int](status, "code.statusnotfound", WithIgnoreCase())
+	if err != nil {
+		t.Fatalf("HandleOf() error = %v", err)
+	}
+	if h.Name() != "Code.StatusNotFound" || h.Value() != 404 || !h.Is(status.Code.StatusNotFound) || h.Is(status.Code.StatusOK) {
+		t.Errorf("HandleOf() = %+v; want Code.StatusNotFound(404)", h)
+	}
+	if _, err := HandleOf[string](status, "Code.StatusOK"); err == nil {
+		t.Errorf("HandleOf[string](Code.StatusOK) error = nil; want a type error")
+	}
+	if _, err := HandleOf[int](status, "Code.Missing"); err == nil {
+		t.Errorf("HandleOf(Code.Missing) error = nil; want an error")
+	}
+}
@@ -0,0 +1,98 @@
+package enum
+
+import "strings"
+
+// MetaFields parses the tag under tagKey of the leaf member of enum named by name, which
+// may be a dotted path, as semicolon-separated "key=value" pairs, such as
+// `meta:"label=Not Found;retryable=false"`, and returns them as a map. Whitespace around
+// keys and values is trimmed, a pair without "=" maps its key to "", and "\;" stands for a
+// literal semicolon, written `meta:"label=a\\;b"` in a struct tag. When a key repeats,
+// the last value wins. Returns nil if enum is not a struct, the member does not exist,
+// or it has no such tag.
+func MetaFields(enum any, name, tagKey string) map[string]string {
+	enumVal, ok := structValue(enum)
+	if !ok {
+		return nil
+	}
+	m, ok := findLeaf(enumVal, name, "enum")
+	if !ok {
+		return nil
+	}
+	tag, ok := m.field.Tag.Lookup(tagKey)
+	if !ok {
+		return nil
+	}
+	return parseMeta(tag)
+}
+
+// Tags returns the raw tags of the leaf members of enum under the configured tag key,
+// keyed by dotted name, with "" for untagged members. Returns nil if enum is not a
+// struct.
+func Tags(enum any, opts ...Option) map[string]string {
+	enumVal, ok := structValue(enum)
+	if !ok {
+		return nil
+	}
+	o := buildOptions(opts)
+	tags := make(map[string]string)
+	for _, m := range leaves(enumVal, o.tagKey()) {
+		tags[m.path] = m.field.Tag.Get(o.tagKey())
+	}
+	return tags
+}
+
+// TagOptions splits the raw tag of the leaf member of enum named by name, which may be a
+// dotted path, into its value and its options such as "desc=..." and "i18n.fr=...", as
+// initialization does. When an option repeats, the last one wins, and options is nil if
+// there are none. ok is false if enum is not a struct, there is no such member, or its
+// tag does not parse.
+func TagOptions(enum any, name string, opts ...Option) (value string, options map[string]string, ok bool) {
+	enumVal, isStruct := structValue(enum)
+	if !isStruct {
+		return "", nil, false
+	}
+	o := buildOptions(opts)
+	m, found := findLeaf(enumVal, name, o.tagKey())
+	if !found {
+		return "", nil, false
+	}
+	value, parsed, err := parseTag(m.field.Tag.Get(o.tagKey()))
+	if err != nil {
+		return "", nil, false
+	}
+	for _, opt := range parsed {
+		if options == nil {
+			options = make(map[string]string)
+		}
+		options[opt.Key] = opt.Value
+	}
+	return value, options, true
+}
+
+// parseMeta parses semicolon-separated "key=value" pairs.
+func parseMeta(tag string) map[string]string {
+	fields := make(map[string]string)
+	for _, pair := range splitEscaped(tag, ';') {
+		key, value, _ := strings.Cut(strings.ReplaceAll(pair, `\;`, ";"), "=")
+		if key = strings.TrimSpace(key); key != "" {
+			fields[key] = strings.TrimSpace(value)
+		}
+	}
+	return fields
+}
+
+// splitEscaped splits s at each sep not preceded by a backslash, keeping escapes intact.
+func splitEscaped(s string, sep byte) []string {
+	var parts []string
+	start := 0
+	for i := 0; i < len(s); i++ {
+		switch s[i] {
+		case '\\':
+			i++
+		case sep:
+			parts = append(parts, s[start:i])
+			start = i + 1
+		}
+	}
+	return append(parts, s[start:])
+}
@@ -0,0 +1,87 @@
+package enum
+
+import (
+	"reflect"
+	"testing"
+)
+
+// MetaStatus carries structured metadata in a meta tag.
+type MetaStatus struct {
+	Code struct {
+		StatusNotFound int `enum:"404" meta:"label=Not Found; retryable=false"`
+		StatusTimeout  int `enum:"408" meta:"label=Timeout;retryable;label=Request Timeout;;"`
+		StatusTeapot   int `enum:"418" meta:"label=Brew\\;Pour"`
+		StatusOK       int `enum:"200"`
+	}
+}
+
+// TestMetaFields tests parsing structured metadata tags.
+func TestMetaFields(t *testing.T) {
+	status := New[MetaStatus]()
+	tests := []struct {
+		name string
+		want map[string]string
+	}{
+		{"Code.StatusNotFound", map[string]string{"label": "Not Found", "retryable": "false"}},
+		{"Code.StatusTimeout", map[string]string{"label": "Request Timeout", "retryable": ""}},
+		{"Code.StatusTeapot", map[string]string{"label": "Brew;Pour"}},
+		{"Code.StatusOK", nil},
+		{"Code.Missing", nil},
+		{"Code", nil},
+	}
+	for _, tt := range tests {
+		if got := MetaFields(status, tt.name, "meta"); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("MetaFields(%s) = %v; want %v", tt.name, got, tt.want)
+		}
+	}
+	if got := MetaFields(42, "A", "meta"); got != nil {
+		t.Errorf("MetaFields(42) = %v; want nil", got)
+	}
+}
+
+// TestTags tests raw tags and parsed options of tagged, untagged, and nested members.
+func TestTags(t *testing.T) {
+	status := New[struct {
+		Code struct {
+			StatusNotFound int `enum:"404,display=Not Found,desc=No such resource"`
+			StatusOK       int
+		}
+		Greeting string `enum:"Hello\\, world,i18n.fr=Bonjour"`
+		Default  string `json:"default"`
+	}]()
+
+	want := map[string]string{
+		"Code.StatusNotFound": "404,display=Not Found,desc=No such resource",
+		"Code.StatusOK":       "",
+		"Greeting":            `Hello\, world,i18n.fr=Bonjour`,
+		"Default":             "",
+	}
+	if got := Tags(status); !reflect.DeepEqual(got, want) {
+		t.Errorf("Tags() = %v; want %v", got, want)
+	}
+	if got := Tags(42); got != nil {
+		t.Errorf("Tags(42) = %v; want nil", got)
+	}
+	if got := Tags(status, WithTagKey("json")); got["Default"] != "default" {
+		t.Errorf("Tags(json)[Default] = %q; want default", got["Default"])
+	}
+
+	// The parsed tags agree with the initialized values and the display helpers.
+	value, options, ok := TagOptions(status, "Code.StatusNotFound")
+	if !ok || value != "404" || status.Code.StatusNotFound != 404 || !reflect.DeepEqual(options, map[string]string{"display": "Not Found", "desc": "No such resource"}) {
+		t.Errorf("TagOptions(Code.StatusNotFound) = %q, %v, %v", value, options, ok)
+	}
+	if options["display"] != Display(status, "Code.StatusNotFound") {
+		t.Errorf("display option %q disagrees with Display", options["display"])
+	}
+	value, options, ok = TagOptions(status, "Greeting")
+	if !ok || value != status.Greeting || options["i18n.fr"] != "Bonjour" {
+		t.Errorf("TagOptions(Greeting) = %q, %v, %v; want %q", value, options, ok, status.Greeting)
+	}
+	if value, options, ok := TagOptions(status, "Code.StatusOK"); !ok || value != "" || options != nil {
+		t.Errorf("TagOptions(Code.StatusOK) = %q, %v, %v; want untagged", value, options, ok)
+	}
+	if _, _, ok := TagOptions(status, "Code"); ok {
+		t.Errorf("TagOptions(Code) ok = true; want false for a group")
+	}
+}
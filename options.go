@@ -0,0 +1,602 @@
+package enum
+
+import (
+	"reflect"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// Options bundles the configuration accepted by NewWith.
+// The zero value reproduces the behavior of New without options: values are read from
+// the "enum" tag, untagged string fields take their field name verbatim, and integer
+// overflows fail. Each field can also be set with the corresponding Option.
+// Fields that only configure queries and formatting, such as Dedup or Columns, fail
+// initialization when set, rather than being silently ignored.
+type Options struct {
+	// TagKey is the struct tag key that holds explicit member values. Defaults to "enum".
+	TagKey string
+
+	// NamingStyle controls how the default value of an untagged string field is derived
+	// from its field name. Defaults to NamingAsIs.
+	NamingStyle NamingStyle
+
+	// Strict rejects sibling members of the same type that share a value.
+	Strict bool
+
+	// WrapOverflow silently truncates integer values that do not fit their field type
+	// instead of failing.
+	WrapOverflow bool
+
+	// WrapSigned accepts negative tags on unsigned fields, reinterpreting them in two's
+	// complement at the field's width, so that `enum:"-1"` on a uint8 field means 255.
+	// Values below the signed range of the width are still rejected.
+	WrapSigned bool
+
+	// FieldFilter, if set, is called for every exported field, including nested structs,
+	// with its path and declaration. Fields for which it returns false are left zeroed,
+	// and nested structs rejected by it are not descended into.
+	FieldFilter func(path string, field reflect.StructField) bool
+
+	// Separator joins the names of nested fields into paths, as used in error messages
+	// and passed to FieldFilter. Defaults to ".".
+	Separator string
+
+	// StartIndex is added to the default value of untagged integer fields.
+	// The default value of the field at index i is StartIndex + i*IndexStep.
+	StartIndex int64
+
+	// IndexStep multiplies the field index in default integer values. Zero means 1.
+	IndexStep int64
+
+	// DescendingIndex makes default integer values count down from StartIndex:
+	// the default value of the field at index i is StartIndex - i*IndexStep.
+	DescendingIndex bool
+
+	// ContinuousIndex numbers the fields of nested groups on from those declared before
+	// them, in declaration order, instead of restarting the index in each group, so
+	// that the default integer values of the whole enum form one sequence. Nested group
+	// fields themselves take no index.
+	ContinuousIndex bool
+
+	// IntDefault, if set, computes the value of untagged integer fields instead of the
+	// index formula. It receives the field's position among the value-bearing fields of
+	// its struct (nested structs and skipped fields are not counted) and its name.
+	IntDefault func(index int, name string) int64
+
+	// StringDefault, if set, computes the value of untagged string fields from their
+	// name, taking precedence over NamingStyle.
+	StringDefault func(name string) string
+
+	// ValuePrefix and ValueSuffix decorate the default value of untagged string fields,
+	// after NamingStyle or StringDefault has been applied.
+	ValuePrefix, ValueSuffix string
+
+	// DecorateTagged also applies ValuePrefix and ValueSuffix to tagged string fields.
+	DecorateTagged bool
+
+	// Overrides maps member paths, joined with Separator, to values that replace the
+	// tagged or default value of the member. Values are checked like SetField values.
+	// Paths that do not name a member cause initialization to fail.
+	Overrides map[string]any
+
+	// Renames maps member paths, joined with Separator, to external names. The external
+	// name replaces the field name when deriving the default value of a string member,
+	// and name lookups given the same option, such as Parse or a handle from Of or Wrap,
+	// accept it alongside the field name; other instances of the type are unaffected.
+	// Renaming to the name of another member of the same struct fails initialization,
+	// as do paths that do not name a member.
+	Renames map[string]string
+
+	// IgnoreCase makes name lookups such as Parse fall back to case-insensitive
+	// matching when no member matches exactly. It has no effect on initialization.
+	IgnoreCase bool
+
+	// DefaultLocale is the locale DisplayName falls back to when a member has no label
+	// for the requested locale or its language.
+	DefaultLocale string
+
+	// UseDisplayNames makes Format list members by their Display label instead of
+	// their field name.
+	UseDisplayNames bool
+
+	// LiteralCases makes GenerateSwitch emit member values as case expressions instead
+	// of constant names.
+	LiteralCases bool
+
+	// TodoCases makes GenerateSwitch emit a skeleton to fill in: a TODO comment on every
+	// case and on the default, which no longer panics.
+	TodoCases bool
+
+	// Nested makes Keys, Values, KeysValues, and Contains consider the leaf members of
+	// nested structs, named by dotted paths, instead of the top-level fields only.
+	Nested bool
+
+	// Ranges makes Contains also accept integer values that fall within a Range member.
+	Ranges bool
+
+	// FieldHook, if set, is called once per member, in declaration order, after its
+	// final value has been set, with the member's path, kind, raw tag, and a copy of its
+	// value. A panic in the hook fails initialization with an error naming the member.
+	FieldHook func(path string, kind reflect.Kind, tag string, value any)
+
+	// Order selects the order in which Keys, Values, KeysValues, and Entries list
+	// members, and WriteTable the members of each group. Defaults to ByDeclaration.
+	Order Order
+
+	// MaxListed caps the lists of AllowedNames and AllowedValues, and the valid names in
+	// the errors of Parse, ParseSlice, HandleOf, and ParseFlags and the panics of
+	// MustParse, at that many items followed by "...". Zero lists every item, except for
+	// the valid names of Parse and the functions built on it, which then list 10.
+	MaxListed int
+
+	// Dedup makes ParseSlice drop values it has already returned.
+	Dedup bool
+
+	// TagFallback makes NameByTag match untagged members by the fmt.Sprint form of
+	// their value.
+	TagFallback bool
+
+	// StrictFlags makes FormatFlags fail on bits that no member covers instead of
+	// appending them in hexadecimal.
+	StrictFlags bool
+
+	// TrimPrefix is removed from the start of the leaf names listed by Keys, KeysValues,
+	// and Entries, so that StatusOK is listed as OK with "Status". Group names in dotted
+	// paths are kept, and a name equal to the prefix is kept whole rather than listed as
+	// "". The members themselves are unaffected.
+	TrimPrefix string
+
+	// Columns selects the columns WriteTable renders, in order. Defaults to ColumnName,
+	// ColumnValue, and ColumnTag, followed by ColumnDescription if a member has a
+	// "desc=" tag option. An empty non-nil slice is an error.
+	Columns []Column
+
+	// Descriptions maps dotted member paths to descriptions that WriteTable uses for
+	// members without a "desc=" tag option, such as those read from comments by
+	// enumdoc.DocDescriptions.
+	Descriptions map[string]string
+
+	// TemplateData, if non-nil, turns tags containing "{{" into text/template templates
+	// executed against it, e.g. `enum:"{{.Version}}"`. The output is used as the tag.
+	TemplateData any
+
+	// EnvExpansion expands environment variables in tags, as os.Expand does with
+	// "${VAR}" and "$VAR", before they are parsed, so that numeric tags may come from
+	// the environment too, and "$$" to a literal "$". Tags of pattern members are left
+	// alone, since "$" anchors regular expressions. Unset variables expand to "" unless
+	// StrictEnv is set, in which case they fail initialization.
+	EnvExpansion bool
+	StrictEnv    bool
+}
+
+// Option configures an Options value. Options are applied in the order given,
+// so when two options set the same field the last one wins.
+// The configuration applies to the whole enum, including nested structs.
+type Option func(*Options)
+
+// buildOptions applies opts in order to zero Options.
+func buildOptions(opts []Option) Options {
+	var o Options
+	for _, opt := range opts {
+		opt(&o)
+	}
+	return o
+}
+
+// queryOnly returns the names of the options set in o that only configure queries and
+// formatting, and so have no effect on initialization. IgnoreCase is not among them,
+// since the handles returned by Of look names up with it.
+func (o *Options) queryOnly() []string {
+	options := []struct {
+		name string
+		set  bool
+	}{
+		{"WithDefaultLocale", o.DefaultLocale != ""},
+		{"WithDisplayNames", o.UseDisplayNames},
+		{"WithLiteralCases", o.LiteralCases},
+		{"WithTodoCases", o.TodoCases},
+		{"WithNested", o.Nested},
+		{"WithRanges", o.Ranges},
+		{"WithOrder", o.Order != ByDeclaration},
+		{"WithMaxListed", o.MaxListed != 0},
+		{"WithDedup", o.Dedup},
+		{"WithTagFallback", o.TagFallback},
+		{"WithStrictFlags", o.StrictFlags},
+		{"WithTrimPrefix", o.TrimPrefix != ""},
+		{"WithColumns", o.Columns != nil},
+		{"WithDescriptions", o.Descriptions != nil},
+	}
+	var names []string
+	for _, opt := range options {
+		if opt.set {
+			names = append(names, opt.name)
+		}
+	}
+	return names
+}
+
+// WithTagKey sets Options.TagKey, the struct tag key holding explicit values.
+func WithTagKey(key string) Option {
+	return func(o *Options) { o.TagKey = key }
+}
+
+// WithNamingStyle sets Options.NamingStyle for untagged string fields.
+func WithNamingStyle(style NamingStyle) Option {
+	return func(o *Options) { o.NamingStyle = style }
+}
+
+// WithStrict sets Options.Strict.
+func WithStrict() Option {
+	return func(o *Options) { o.Strict = true }
+}
+
+// WithWrapOverflow sets Options.WrapOverflow.
+func WithWrapOverflow() Option {
+	return func(o *Options) { o.WrapOverflow = true }
+}
+
+// WithWrapSigned sets Options.WrapSigned.
+func WithWrapSigned() Option {
+	return func(o *Options) { o.WrapSigned = true }
+}
+
+// WithFieldFilter sets Options.FieldFilter.
+func WithFieldFilter(filter func(path string, field reflect.StructField) bool) Option {
+	return func(o *Options) { o.FieldFilter = filter }
+}
+
+// WithSeparator sets Options.Separator, the separator joining nested field names.
+func WithSeparator(sep string) Option {
+	return func(o *Options) { o.Separator = sep }
+}
+
+// WithStartIndex sets Options.StartIndex, so untagged integer fields count from start,
+// e.g. WithStartIndex(1) reserves 0 for an unknown value.
+func WithStartIndex(start int64) Option {
+	return func(o *Options) { o.StartIndex = start }
+}
+
+// WithStep sets Options.IndexStep, the distance between consecutive default integers.
+func WithStep(step int64) Option {
+	return func(o *Options) { o.IndexStep = step }
+}
+
+// WithDescendingIndex sets Options.DescendingIndex and Options.StartIndex, so untagged
+// integer fields count down from from, e.g. for priorities where the first-declared
+// member ranks highest. It composes with WithStep.
+func WithDescendingIndex(from int64) Option {
+	return func(o *Options) {
+		o.StartIndex = from
+		o.DescendingIndex = true
+	}
+}
+
+// WithContinuousIndex sets Options.ContinuousIndex, so that the default index runs on
+// through nested groups, e.g. for priorities counting down across the whole enum with
+// WithDescendingIndex.
+func WithContinuousIndex() Option {
+	return func(o *Options) { o.ContinuousIndex = true }
+}
+
+// WithIntDefault sets Options.IntDefault. Explicit tags still take precedence.
+func WithIntDefault(fn func(index int, name string) int64) Option {
+	return func(o *Options) { o.IntDefault = fn }
+}
+
+// WithStringDefault sets Options.StringDefault. Explicit tags still take precedence.
+func WithStringDefault(fn func(name string) string) Option {
+	return func(o *Options) { o.StringDefault = fn }
+}
+
+// WithValuePrefix sets Options.ValuePrefix, e.g. "http." for "http.StatusOK".
+func WithValuePrefix(prefix string) Option {
+	return func(o *Options) { o.ValuePrefix = prefix }
+}
+
+// WithValueSuffix sets Options.ValueSuffix, e.g. ".v1" for "StatusOK.v1".
+func WithValueSuffix(suffix string) Option {
+	return func(o *Options) { o.ValueSuffix = suffix }
+}
+
+// WithDecorateTagged sets Options.DecorateTagged.
+func WithDecorateTagged() Option {
+	return func(o *Options) { o.DecorateTagged = true }
+}
+
+// WithOverrides sets Options.Overrides, e.g.
+// WithOverrides(map[string]any{"Code.StatusOK": 299}).
+func WithOverrides(overrides map[string]any) Option {
+	return func(o *Options) { o.Overrides = overrides }
+}
+
+// WithRename sets Options.Renames, e.g. WithRename(map[string]string{"StatusOK": "OK"}).
+func WithRename(renames map[string]string) Option {
+	return func(o *Options) { o.Renames = renames }
+}
+
+// WithIgnoreCase sets Options.IgnoreCase. An exact match always takes precedence over
+// a case-insensitive one, and a name that folds to several members is an error.
+func WithIgnoreCase() Option {
+	return func(o *Options) { o.IgnoreCase = true }
+}
+
+// WithDefaultLocale sets Options.DefaultLocale, e.g. WithDefaultLocale("en").
+func WithDefaultLocale(locale string) Option {
+	return func(o *Options) { o.DefaultLocale = locale }
+}
+
+// WithDisplayNames sets Options.UseDisplayNames.
+func WithDisplayNames() Option {
+	return func(o *Options) { o.UseDisplayNames = true }
+}
+
+// WithLiteralCases sets Options.LiteralCases.
+func WithLiteralCases() Option {
+	return func(o *Options) { o.LiteralCases = true }
+}
+
+// WithTodoCases sets Options.TodoCases.
+func WithTodoCases() Option {
+	return func(o *Options) { o.TodoCases = true }
+}
+
+// WithNested sets Options.Nested.
+func WithNested() Option {
+	return func(o *Options) { o.Nested = true }
+}
+
+// WithRanges sets Options.Ranges.
+func WithRanges() Option {
+	return func(o *Options) { o.Ranges = true }
+}
+
+// WithFieldHook sets Options.FieldHook, e.g. to audit or instrument initialization.
+func WithFieldHook(hook func(path string, kind reflect.Kind, tag string, value any)) Option {
+	return func(o *Options) { o.FieldHook = hook }
+}
+
+// WithOrder sets Options.Order.
+func WithOrder(order Order) Option {
+	return func(o *Options) { o.Order = order }
+}
+
+// WithMaxListed sets Options.MaxListed.
+func WithMaxListed(n int) Option {
+	return func(o *Options) { o.MaxListed = n }
+}
+
+// WithDedup sets Options.Dedup.
+func WithDedup() Option {
+	return func(o *Options) { o.Dedup = true }
+}
+
+// WithTagFallback sets Options.TagFallback.
+func WithTagFallback() Option {
+	return func(o *Options) { o.TagFallback = true }
+}
+
+// WithStrictFlags sets Options.StrictFlags.
+func WithStrictFlags() Option {
+	return func(o *Options) { o.StrictFlags = true }
+}
+
+// WithTrimPrefix sets Options.TrimPrefix.
+func WithTrimPrefix(prefix string) Option {
+	return func(o *Options) { o.TrimPrefix = prefix }
+}
+
+// WithColumns sets Options.Columns. Calling it without columns selects none, which
+// WriteTable rejects, rather than the defaults.
+func WithColumns(columns ...Column) Option {
+	return func(o *Options) { o.Columns = append([]Column{}, columns...) }
+}
+
+// WithDescriptions sets Options.Descriptions.
+func WithDescriptions(descriptions map[string]string) Option {
+	return func(o *Options) { o.Descriptions = descriptions }
+}
+
+// WithTemplateData sets Options.TemplateData, enabling text/template tags.
+func WithTemplateData(data any) Option {
+	return func(o *Options) { o.TemplateData = data }
+}
+
+// WithEnvExpansion sets Options.EnvExpansion.
+func WithEnvExpansion() Option {
+	return func(o *Options) { o.EnvExpansion = true }
+}
+
+// WithStrictEnv sets Options.EnvExpansion and Options.StrictEnv, so that tags
+// referencing unset environment variables fail initialization.
+func WithStrictEnv() Option {
+	return func(o *Options) { o.EnvExpansion, o.StrictEnv = true, true }
+}
+
+// outputName returns the dotted path of a member as listed by Keys and friends, with
+// TrimPrefix removed from its leaf name.
+func (o *Options) outputName(path string) string {
+	if o.TrimPrefix == "" {
+		return path
+	}
+	i := strings.LastIndex(path, ".") + 1
+	if name := path[i:]; name != o.TrimPrefix {
+		path = path[:i] + strings.TrimPrefix(name, o.TrimPrefix)
+	}
+	return path
+}
+
+// tagKey returns the configured tag key, falling back to "enum".
+func (o *Options) tagKey() string {
+	if o.TagKey == "" {
+		return "enum"
+	}
+	return o.TagKey
+}
+
+// indexValue returns the default value of an untagged integer field at index i.
+func (o *Options) indexValue(i int) int64 {
+	step := o.IndexStep
+	if step == 0 {
+		step = 1
+	}
+	if o.DescendingIndex {
+		step = -step
+	}
+	return o.StartIndex + int64(i)*step
+}
+
+// separator returns the configured path separator, falling back to ".".
+func (o *Options) separator() string {
+	if o.Separator == "" {
+		return "."
+	}
+	return o.Separator
+}
+
+// join appends name to the path prefix using the configured separator.
+func (o *Options) join(prefix, name string) string {
+	if prefix == "" {
+		return name
+	}
+	return prefix + o.separator() + name
+}
+
+// matchesRenamed reports whether name refers to the member at the dotted path, each of
+// its segments being either the field name or the external name given by Renames to the
+// field's path.
+func (o *Options) matchesRenamed(path, name string) bool {
+	segments, names := strings.Split(path, "."), strings.Split(name, ".")
+	if len(segments) != len(names) {
+		return false
+	}
+	for i, segment := range segments {
+		if names[i] == segment {
+			continue
+		}
+		if alias, ok := o.Renames[strings.Join(segments[:i+1], o.separator())]; !ok || alias != names[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// NamingStyle selects how an untagged string field's default value is derived from its name.
+type NamingStyle int
+
+const (
+	// NamingAsIs uses the field name unchanged, e.g. StatusNotFound.
+	NamingAsIs NamingStyle = iota
+	// NamingSnake converts the field name to snake case, e.g. status_not_found.
+	NamingSnake
+	// NamingScreamingSnake converts the field name to upper snake case, e.g. STATUS_NOT_FOUND.
+	NamingScreamingSnake
+	// NamingKebab converts the field name to kebab case, e.g. status-not-found.
+	NamingKebab
+	// NamingCamel lowercases the first word of the field name, e.g. statusNotFound.
+	NamingCamel
+	// NamingLower lowercases the field name, e.g. statusnotfound.
+	NamingLower
+	// NamingUpper uppercases the field name, e.g. STATUSNOTFOUND.
+	NamingUpper
+)
+
+// apply converts a field name according to the naming style.
+func (s NamingStyle) apply(name string) string {
+	switch s {
+	case NamingSnake:
+		return strings.ToLower(strings.Join(splitWords(name), "_"))
+	case NamingScreamingSnake:
+		return strings.ToUpper(strings.Join(splitWords(name), "_"))
+	case NamingKebab:
+		return strings.ToLower(strings.Join(splitWords(name), "-"))
+	case NamingCamel:
+		words := splitWords(name)
+		for i, word := range words {
+			if i == 0 {
+				words[i] = strings.ToLower(word)
+			} else {
+				r, size := utf8.DecodeRuneInString(word)
+				words[i] = string(unicode.ToUpper(r)) + strings.ToLower(word[size:])
+			}
+		}
+		return strings.Join(words, "")
+	case NamingLower:
+		return strings.ToLower(name)
+	case NamingUpper:
+		return strings.ToUpper(name)
+	}
+	return name
+}
+
+// splitWords splits a Go identifier into words at case changes and underscores.
+// Runs of capitals are kept together as acronyms, e.g. "HTTPStatusOK" yields
+// ["HTTP", "Status", "OK"]. Digits stay attached to the preceding word.
+func splitWords(name string) []string {
+	var words []string
+	runes := []rune(name)
+	start := 0
+	for i := 0; i < len(runes); i++ {
+		r := runes[i]
+		if r == '_' {
+			if i > start {
+				words = append(words, string(runes[start:i]))
+			}
+			start = i + 1
+			continue
+		}
+		if i == start || !unicode.IsUpper(r) {
+			continue
+		}
+		prev := runes[i-1]
+		// Break before an upper-case rune that follows a lower-case rune or digit,
+		// or that starts a new word after an acronym ("HTTPStatus" -> "HTTP", "Status").
+		nextLower := i+1 < len(runes) && unicode.IsLower(runes[i+1])
+		if unicode.IsLower(prev) || unicode.IsDigit(prev) || (unicode.IsUpper(prev) && nextLower) {
+			words = append(words, string(runes[start:i]))
+			start = i
+		}
+	}
+	if start < len(runes) {
+		words = append(words, string(runes[start:]))
+	}
+	return words
+}
+
+// Order selects the order in which members are listed.
+type Order int
+
+const (
+	// ByDeclaration lists members in declaration order, depth first.
+	ByDeclaration Order = iota
+	// ByName lists members sorted by their (dotted) name.
+	ByName
+	// ByValue lists members sorted by ascending value. All members listed must hold
+	// strings, or all integers; members of other or mixed kinds are listed in
+	// declaration order instead, and KeysE and WriteTable report the error.
+	ByValue
+	// ByValueDesc lists members sorted by descending value, like ByValue.
+	ByValueDesc
+)
+
+// Column is a column of the table rendered by WriteTable.
+type Column int
+
+const (
+	// ColumnName holds member names, indented under their group.
+	ColumnName Column = iota
+	// ColumnValue holds member values, with strings quoted.
+	ColumnValue
+	// ColumnTag holds the raw tag of each member.
+	ColumnTag
+	// ColumnDescription holds the "desc=" tag option of each member.
+	ColumnDescription
+)
+
+// columnHeaders are the header cells of the table columns.
+var columnHeaders = map[Column]string{
+	ColumnName:        "NAME",
+	ColumnValue:       "VALUE",
+	ColumnTag:         "TAG",
+	ColumnDescription: "DESCRIPTION",
+}
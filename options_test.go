@@ -0,0 +1,691 @@
+package enum
+
+import (
+	"fmt"
+	"hash/fnv"
+	"reflect"
+	"regexp"
+	"strings"
+	"testing"
+)
+
+// TestNewWithZeroOptions tests that NewWith with zero Options matches New.
+func TestNewWithZeroOptions(t *testing.T) {
+	type Status struct {
+		StatusOK       int `enum:"200"`
+		StatusNotFound string
+	}
+	got, err := NewWith[Status](Options{})
+	if err != nil {
+		t.Fatalf("NewWith returned error: %v", err)
+	}
+	if want := New[Status](); got != want {
+		t.Errorf("got %+v, want %+v", got, want)
+	}
+}
+
+// TestNewWithTagKey tests that a custom tag key replaces the "enum" tag.
+func TestNewWithTagKey(t *testing.T) {
+	got, err := NewWith[struct {
+		StatusOK       int `enum:"1" code:"200"`
+		StatusNotFound int `code:"404"`
+	}](Options{TagKey: "code"})
+	if err != nil {
+		t.Fatalf("NewWith returned error: %v", err)
+	}
+	if got.StatusOK != 200 || got.StatusNotFound != 404 {
+		t.Errorf("got %+v, want {StatusOK: 200, StatusNotFound: 404}", got)
+	}
+}
+
+// TestEntriesWithTagKey tests that entries report the tags under a custom tag key.
+func TestEntriesWithTagKey(t *testing.T) {
+	type Status struct {
+		StatusOK       int     `enum:"1" code:"200"`
+		StatusNotFound int     `code:"404"`
+		All            []Entry `code:"entries"`
+	}
+	key := WithTagKey("code")
+	status := New[Status](key)
+	for _, entries := range [][]Entry{Entries(status, key), Of[Status](key).Entries(), status.All} {
+		if len(entries) != 2 || entries[0].Tag != "200" || entries[1].Tag != "404" {
+			t.Errorf("entries = %+v; want the tags 200 and 404", entries)
+		}
+	}
+}
+
+// TestNewWithNamingStyle tests each naming style on untagged string fields.
+func TestNewWithNamingStyle(t *testing.T) {
+	tests := []struct {
+		style NamingStyle
+		want  string
+	}{
+		{NamingAsIs, "HTTPStatusNotFound"},
+		{NamingSnake, "http_status_not_found"},
+		{NamingScreamingSnake, "HTTP_STATUS_NOT_FOUND"},
+		{NamingKebab, "http-status-not-found"},
+		{NamingCamel, "httpStatusNotFound"},
+		{NamingLower, "httpstatusnotfound"},
+		{NamingUpper, "HTTPSTATUSNOTFOUND"},
+	}
+	for _, tt := range tests {
+		got, err := NewWith[struct {
+			HTTPStatusNotFound string
+			Tagged             string `enum:"Tagged_Value"`
+		}](Options{NamingStyle: tt.style})
+		if err != nil {
+			t.Fatalf("NewWith(%v) returned error: %v", tt.style, err)
+		}
+		if got.HTTPStatusNotFound != tt.want {
+			t.Errorf("NewWith(%v).HTTPStatusNotFound = %q; want %q", tt.style, got.HTTPStatusNotFound, tt.want)
+		}
+		if got.Tagged != "Tagged_Value" {
+			t.Errorf("NewWith(%v).Tagged = %q; want %q", tt.style, got.Tagged, "Tagged_Value")
+		}
+	}
+
+	// Camel case capitalizes whole runes, not bytes.
+	got := New[struct{ Speed_über string }](WithNamingStyle(NamingCamel))
+	if got.Speed_über != "speedÜber" {
+		t.Errorf("NamingCamel Speed_über = %q; want %q", got.Speed_über, "speedÜber")
+	}
+}
+
+// TestSplitWords tests identifier splitting around acronyms, digits, and underscores.
+func TestSplitWords(t *testing.T) {
+	tests := map[string][]string{
+		"StatusOK":      {"Status", "OK"},
+		"HTTPStatus":    {"HTTP", "Status"},
+		"Status404Page": {"Status404", "Page"},
+		"Snake_Case":    {"Snake", "Case"},
+		"A":             {"A"},
+	}
+	for name, want := range tests {
+		if got := splitWords(name); !reflect.DeepEqual(got, want) {
+			t.Errorf("splitWords(%q) = %q; want %q", name, got, want)
+		}
+	}
+}
+
+// TestNewWithStrict tests that strict mode rejects duplicate sibling values.
+func TestNewWithStrict(t *testing.T) {
+	type Status struct {
+		StatusOK      int `enum:"200"`
+		StatusSuccess int `enum:"200"`
+	}
+	if _, err := NewWith[Status](Options{}); err != nil {
+		t.Fatalf("NewWith without Strict returned error: %v", err)
+	}
+	_, err := NewWith[Status](Options{Strict: true})
+	if err == nil || !strings.Contains(err.Error(), "StatusSuccess") || !strings.Contains(err.Error(), "StatusOK") {
+		t.Errorf("NewWith with Strict error = %v; want duplicate error naming both fields", err)
+	}
+}
+
+// TestNewWithWrapOverflow tests that overflowing values are truncated instead of rejected.
+func TestNewWithWrapOverflow(t *testing.T) {
+	type Small struct {
+		Signed   int8  `enum:"200"`
+		Unsigned uint8 `enum:"300"`
+	}
+	if _, err := NewWith[Small](Options{}); err == nil {
+		t.Fatal("NewWith without WrapOverflow returned nil error; want overflow error")
+	}
+	got, err := NewWith[Small](Options{WrapOverflow: true})
+	if err != nil {
+		t.Fatalf("NewWith with WrapOverflow returned error: %v", err)
+	}
+	if got.Signed != -56 || got.Unsigned != 44 {
+		t.Errorf("got %+v, want {Signed: -56, Unsigned: 44}", got)
+	}
+}
+
+// TestWithWrapSigned tests that negative tags on unsigned fields wrap only when allowed.
+func TestWithWrapSigned(t *testing.T) {
+	type Wire struct {
+		Byte  uint8  `enum:"-1"`
+		Short uint16 `enum:"-1"`
+		Min   uint8  `enum:"-128"`
+		Word  uint64 `enum:"-2"`
+		Plain uint8  `enum:"7"`
+	}
+	if _, err := TryNew[Wire](); err == nil {
+		t.Fatal("TryNew without WithWrapSigned returned nil error; want negative tags rejected")
+	}
+	got, err := TryNew[Wire](WithWrapSigned())
+	if err != nil {
+		t.Fatalf("TryNew with WithWrapSigned returned error: %v", err)
+	}
+	if got.Byte != 255 || got.Short != 65535 || got.Min != 128 || got.Word != 1<<64-2 || got.Plain != 7 {
+		t.Errorf("got %+v, want {Byte:255 Short:65535 Min:128 Word:%d Plain:7}", got, uint64(1<<64-2))
+	}
+
+	_, err = TryNew[struct {
+		Byte uint8 `enum:"-129"`
+	}](WithWrapSigned())
+	if want := "field Byte: invalid enum tag: value -129 overflows the signed range of 8-bit uint8"; err == nil || err.Error() != want {
+		t.Errorf("TryNew error = %v; want %q", err, want)
+	}
+}
+
+// TestNewWithFieldFilter tests that filtered fields and groups stay zeroed.
+func TestNewWithFieldFilter(t *testing.T) {
+	var paths []string
+	got, err := NewWith[struct {
+		Code struct {
+			StatusOK int `enum:"200"`
+		}
+		Type struct {
+			StatusOK string
+		}
+		Skipped string
+	}](Options{FieldFilter: func(path string, field reflect.StructField) bool {
+		paths = append(paths, path)
+		return path != "Type" && field.Name != "Skipped"
+	}})
+	if err != nil {
+		t.Fatalf("NewWith returned error: %v", err)
+	}
+	if got.Code.StatusOK != 200 || got.Type.StatusOK != "" || got.Skipped != "" {
+		t.Errorf("got %+v, want only Code initialized", got)
+	}
+	want := []string{"Code", "Code.StatusOK", "Type", "Skipped"}
+	if !reflect.DeepEqual(paths, want) {
+		t.Errorf("FieldFilter paths = %q; want %q", paths, want)
+	}
+}
+
+// TestNewWithSeparator tests that nested paths in errors use the configured separator.
+func TestNewWithSeparator(t *testing.T) {
+	_, err := NewWith[struct {
+		Code struct {
+			StatusOK int8 `enum:"200"`
+		}
+	}](Options{Separator: "/"})
+	if err == nil || !strings.Contains(err.Error(), "field Code/StatusOK:") {
+		t.Errorf("NewWith error = %v; want error mentioning field Code/StatusOK", err)
+	}
+}
+
+// TestNewOptionsNested tests that functional options reach nested structs.
+func TestNewOptionsNested(t *testing.T) {
+	got := New[struct {
+		Outer struct {
+			Inner struct {
+				StatusNotFound string
+				StatusOK       int `code:"200"`
+			}
+		}
+	}](WithTagKey("code"), WithNamingStyle(NamingKebab))
+	if got.Outer.Inner.StatusNotFound != "status-not-found" || got.Outer.Inner.StatusOK != 200 {
+		t.Errorf("got %+v, want {StatusNotFound: status-not-found, StatusOK: 200}", got.Outer.Inner)
+	}
+}
+
+// TestNewOptionsLastWins tests that later options override earlier ones.
+func TestNewOptionsLastWins(t *testing.T) {
+	got := New[struct {
+		StatusOK string
+	}](WithNamingStyle(NamingUpper), WithNamingStyle(NamingSnake))
+	if got.StatusOK != "status_ok" {
+		t.Errorf("got %q, want %q", got.StatusOK, "status_ok")
+	}
+}
+
+// TestTryNew tests that TryNew returns errors instead of panicking.
+func TestTryNew(t *testing.T) {
+	if _, err := TryNew[struct{ A int8 }](); err != nil {
+		t.Errorf("TryNew returned error: %v", err)
+	}
+	if _, err := TryNew[struct {
+		A int8 `enum:"200"`
+	}](); err == nil {
+		t.Errorf("TryNew returned nil error; want overflow error")
+	}
+	if _, err := TryNew[struct {
+		A int8 `enum:"200"`
+	}](WithWrapOverflow()); err != nil {
+		t.Errorf("TryNew with WithWrapOverflow returned error: %v", err)
+	}
+}
+
+// TestQueryOptionsRejected tests that the constructors reject options that only apply to
+// queries, while the query functions accept them.
+func TestQueryOptionsRejected(t *testing.T) {
+	type Status struct {
+		StatusOK       int `enum:"200"`
+		StatusNotFound int `enum:"404"`
+	}
+	want := "options WithDedup, WithColumns do not apply to initialization"
+	if _, err := TryNew[Status](WithDedup(), WithColumns(ColumnName)); err == nil || err.Error() != want {
+		t.Errorf("TryNew(WithDedup, WithColumns) error = %v; want %q", err, want)
+	}
+	if _, err := NewWith[Status](Options{Order: ByName}); err == nil || !strings.Contains(err.Error(), "WithOrder") {
+		t.Errorf("NewWith(Order) error = %v; want WithOrder rejected", err)
+	}
+	if err := ValidateType(reflect.TypeOf(Status{}), WithNested()); err == nil {
+		t.Error("ValidateType(WithNested) returned nil error; want WithNested rejected")
+	}
+	func() {
+		defer func() {
+			if r := recover(); r == nil || !strings.Contains(fmt.Sprint(r), "WithMaxListed") {
+				t.Errorf("Of(WithMaxListed) panic = %v; want WithMaxListed rejected", r)
+			}
+		}()
+		Of[Status](WithMaxListed(1))
+	}()
+
+	// Lookup options shared by Of's handle are still accepted.
+	if _, err := TryNew[Status](WithIgnoreCase()); err != nil {
+		t.Errorf("TryNew(WithIgnoreCase) returned error: %v", err)
+	}
+	if got := Keys(New[Status](), WithOrder(ByValueDesc)); len(got) != 2 || got[0] != "StatusNotFound" {
+		t.Errorf("Keys(WithOrder) = %v; want StatusNotFound first", got)
+	}
+}
+
+// TestWithStartIndex tests untagged integer defaults offset by a start index.
+func TestWithStartIndex(t *testing.T) {
+	got := New[struct {
+		Unknown  int `enum:"0"`
+		Active   int
+		Inactive uint8
+		Nested   struct {
+			First  int
+			Second int
+		}
+	}](WithStartIndex(1))
+	if got.Unknown != 0 || got.Active != 2 || got.Inactive != 3 {
+		t.Errorf("got %+v, want {Unknown: 0, Active: 2, Inactive: 3}", got)
+	}
+	if got.Nested.First != 1 || got.Nested.Second != 2 {
+		t.Errorf("got Nested %+v, want {First: 1, Second: 2}", got.Nested)
+	}
+}
+
+// TestWithStartIndexStep tests the start + index*step formula.
+func TestWithStartIndexStep(t *testing.T) {
+	got := New[struct {
+		A, B, C int
+	}](WithStep(10), WithStartIndex(100))
+	if got.A != 100 || got.B != 110 || got.C != 120 {
+		t.Errorf("got %+v, want {A: 100, B: 110, C: 120}", got)
+	}
+}
+
+// TestWithStartIndexOverflow tests that a large start still overflows narrow fields.
+func TestWithStartIndexOverflow(t *testing.T) {
+	_, err := TryNew[struct {
+		A int8
+		B int8
+	}](WithStartIndex(127))
+	if err == nil || !strings.Contains(err.Error(), "field B") {
+		t.Errorf("TryNew error = %v; want overflow error for field B", err)
+	}
+	if _, err := TryNew[struct{ A uint8 }](WithStartIndex(-1)); err == nil {
+		t.Errorf("TryNew with negative default on uint8 returned nil error; want error")
+	}
+}
+
+// TestWithDescendingIndex tests counting down with steps, tags, and nested groups.
+func TestWithDescendingIndex(t *testing.T) {
+	type Priority struct {
+		Critical int
+		High     int
+		Pinned   int `enum:"42"`
+		Low      int
+		Group    struct {
+			First  int
+			Second int
+		}
+	}
+	got := New[Priority](WithDescendingIndex(10))
+	if got.Critical != 10 || got.High != 9 || got.Pinned != 42 || got.Low != 7 || got.Group.First != 10 || got.Group.Second != 9 {
+		t.Errorf("got %+v, want counts down from 10", got)
+	}
+	got = New[Priority](WithDescendingIndex(100), WithStep(10))
+	if got.Critical != 100 || got.High != 90 || got.Low != 70 {
+		t.Errorf("got %+v, want counts down from 100 by 10", got)
+	}
+	got = New[Priority](WithDescendingIndex(10), WithContinuousIndex())
+	if got.Low != 7 || got.Group.First != 6 || got.Group.Second != 5 {
+		t.Errorf("got %+v, want counts down from 10 across the group", got)
+	}
+}
+
+// TestWithContinuousIndex tests numbering that runs on through nested groups.
+func TestWithContinuousIndex(t *testing.T) {
+	got := New[struct {
+		A     int
+		Group struct {
+			B int
+			C string
+			D uint8
+		}
+		E     int
+		Other struct {
+			F int
+		}
+	}](WithContinuousIndex(), WithStartIndex(1))
+	if got.A != 1 || got.Group.B != 2 || got.Group.D != 4 || got.E != 5 || got.Other.F != 6 {
+		t.Errorf("got %+v, want 1 through 6 across the groups", got)
+	}
+}
+
+// TestWithDescendingIndexUnsigned tests that counting below zero panics on unsigned fields.
+func TestWithDescendingIndexUnsigned(t *testing.T) {
+	defer func() {
+		if r := recover(); r == nil || !strings.Contains(fmt.Sprint(r), "field C") {
+			t.Errorf("New() panic = %v; want negative default error for field C", r)
+		}
+	}()
+	New[struct {
+		A uint8
+		B uint8
+		C uint8
+	}](WithDescendingIndex(1))
+}
+
+// TestWithIntDefault tests a hash-based integer default that leaves tagged fields alone.
+func TestWithIntDefault(t *testing.T) {
+	hash := func(index int, name string) int64 {
+		h := fnv.New32a()
+		h.Write([]byte(name))
+		return int64(h.Sum32())
+	}
+	var positions []int
+	got := New[struct {
+		Created  int64
+		Nested   struct{ Deleted int64 }
+		Updated  int64
+		Reserved int64 `enum:"7"`
+		Label    string
+		Archived uint32
+	}](WithIntDefault(func(index int, name string) int64 {
+		positions = append(positions, index)
+		return hash(index, name)
+	}))
+	if got.Created != hash(0, "Created") || got.Updated != hash(0, "Updated") || got.Nested.Deleted != hash(0, "Deleted") {
+		t.Errorf("got %+v, want FNV hashes of the field names", got)
+	}
+	if got.Reserved != 7 {
+		t.Errorf("got Reserved %d, want tagged value 7", got.Reserved)
+	}
+	if int64(got.Archived) != hash(0, "Archived") {
+		t.Errorf("got Archived %d, want FNV hash of the field name", got.Archived)
+	}
+	// The tagged Reserved, at position 2, is not passed to the callback.
+	if want := []int{0, 0, 1, 4}; !reflect.DeepEqual(positions, want) {
+		t.Errorf("callback positions = %v; want %v", positions, want)
+	}
+}
+
+// TestWithStringDefault tests a lowercasing string default that leaves tagged fields alone.
+func TestWithStringDefault(t *testing.T) {
+	got := New[struct {
+		StatusOK string
+		Tagged   string `enum:"KEEP"`
+		Group    struct{ StatusNotFound string }
+	}](WithStringDefault(strings.ToLower), WithNamingStyle(NamingUpper))
+	if got.StatusOK != "statusok" || got.Tagged != "KEEP" || got.Group.StatusNotFound != "statusnotfound" {
+		t.Errorf("got %+v, want {StatusOK: statusok, Tagged: KEEP, Group: {StatusNotFound: statusnotfound}}", got)
+	}
+}
+
+// TestDefaultCallbackPanic tests that callback panics are reported with the field path.
+func TestDefaultCallbackPanic(t *testing.T) {
+	_, err := TryNew[struct {
+		Group struct{ Broken int }
+	}](WithIntDefault(func(int, string) int64 { panic("boom") }))
+	if err == nil || !strings.Contains(err.Error(), "Group.Broken") || !strings.Contains(err.Error(), "boom") {
+		t.Errorf("TryNew error = %v; want error mentioning Group.Broken and boom", err)
+	}
+
+	// Callbacks are not called for tagged members, so they cannot fail them.
+	var called []string
+	got, err := TryNew[struct {
+		Signed   int       `enum:"1"`
+		Unsigned uint      `enum:"2"`
+		Label    CodeLabel `enum:"3:Three"`
+		Default  int
+	}](WithIntDefault(func(_ int, name string) int64 {
+		called = append(called, name)
+		if name != "Default" {
+			panic("boom")
+		}
+		return 9
+	}))
+	if err != nil || got.Signed != 1 || got.Unsigned != 2 || got.Label.Code != 3 || got.Default != 9 {
+		t.Errorf("TryNew() = %+v, %v; want tagged values and Default 9", got, err)
+	}
+	if !reflect.DeepEqual(called, []string{"Default"}) {
+		t.Errorf("IntDefault called for %v; want only Default", called)
+	}
+}
+
+// TestWithValuePrefixSuffix tests decoration of default string values after transforms.
+func TestWithValuePrefixSuffix(t *testing.T) {
+	type Status struct {
+		StatusOK string
+		Tagged   string `enum:"custom"`
+		Group    struct{ StatusNotFound string }
+	}
+	got := New[Status](WithValuePrefix("http."), WithValueSuffix(".v1"), WithNamingStyle(NamingSnake))
+	if got.StatusOK != "http.status_ok.v1" || got.Group.StatusNotFound != "http.status_not_found.v1" {
+		t.Errorf("got %+v, want snake-cased values decorated with http. and .v1", got)
+	}
+	if got.Tagged != "custom" {
+		t.Errorf("got Tagged %q, want undecorated %q", got.Tagged, "custom")
+	}
+
+	got = New[Status](WithValuePrefix("http."), WithDecorateTagged())
+	if got.Tagged != "http.custom" || got.StatusOK != "http.StatusOK" {
+		t.Errorf("got %+v, want tagged and untagged values decorated", got)
+	}
+}
+
+// TestWithOverrides tests nested overrides, type mismatches, and unknown paths.
+func TestWithOverrides(t *testing.T) {
+	type HttpStatus struct {
+		Code struct {
+			StatusOK       int8 `enum:"100"`
+			StatusNotFound int8 `enum:"44"`
+		}
+		Name string
+	}
+
+	got := New[HttpStatus](WithOverrides(map[string]any{"Code.StatusOK": 99, "Name": "Renamed"}))
+	if got.Code.StatusOK != 99 || got.Code.StatusNotFound != 44 || got.Name != "Renamed" {
+		t.Errorf("got %+v, want {Code: {StatusOK: 99, StatusNotFound: 44}, Name: Renamed}", got)
+	}
+
+	_, err := TryNew[HttpStatus](WithOverrides(map[string]any{"Code.StatusOK": "ok"}))
+	if err == nil || !strings.Contains(err.Error(), "Code.StatusOK") {
+		t.Errorf("TryNew with mismatched override error = %v; want error naming Code.StatusOK", err)
+	}
+	_, err = TryNew[HttpStatus](WithOverrides(map[string]any{"Code.StatusOK": 299}))
+	if err == nil || !strings.Contains(err.Error(), "overflows int8") {
+		t.Errorf("TryNew with overflowing override error = %v; want overflow error", err)
+	}
+	_, err = TryNew[HttpStatus](WithOverrides(map[string]any{"Code.StatusOk": 1, "Nmae": "x", "Code": 1}))
+	if err == nil || !strings.Contains(err.Error(), "Code, Code.StatusOk, Nmae") {
+		t.Errorf("TryNew with unknown override error = %v; want error listing Code, Code.StatusOk, Nmae", err)
+	}
+}
+
+// TestWithRename tests renamed default values and lookups by either name.
+func TestWithRename(t *testing.T) {
+	type RenamedStatus struct {
+		StatusOK string
+		Code     struct {
+			StatusNotFound int `enum:"404"`
+		}
+	}
+	renames := WithRename(map[string]string{
+		"StatusOK":            "OK",
+		"Code.StatusNotFound": "NotFound",
+	})
+	got := New[RenamedStatus](renames, WithNamingStyle(NamingLower))
+	if got.StatusOK != "ok" || got.Code.StatusNotFound != 404 {
+		t.Errorf("got %+v, want {StatusOK: ok, Code: {StatusNotFound: 404}}", got)
+	}
+
+	for _, name := range []string{"StatusOK", "OK"} {
+		if v, err := Parse[string](got, name, renames); err != nil || v != "ok" {
+			t.Errorf("Parse(%q) = %q, %v; want %q, nil", name, v, err, "ok")
+		}
+	}
+	for _, name := range []string{"Code.StatusNotFound", "Code.NotFound"} {
+		if v, err := Parse[int](got, name, renames); err != nil || v != 404 {
+			t.Errorf("Parse(%q) = %d, %v; want 404, nil", name, v, err)
+		}
+	}
+	if !Contains(got, "ok") {
+		t.Errorf("Contains(%v, %q) = false; want true", got, "ok")
+	}
+	if v, err := Wrap(got, renames).Parse("Code.NotFound"); err != nil || v != 404 {
+		t.Errorf("Wrap().Parse(%q) = %v, %v; want 404, nil", "Code.NotFound", v, err)
+	}
+
+	// External names belong to the lookups given them, not to every instance of the type.
+	plain := New[RenamedStatus]()
+	if _, err := Parse[string](plain, "OK"); err == nil {
+		t.Error(`Parse("OK") without WithRename succeeded; want an unknown member error`)
+	}
+	if Of[RenamedStatus]().Contains("Code.NotFound") {
+		t.Error(`Of().Contains("Code.NotFound") = true; want false without WithRename`)
+	}
+}
+
+// TestWithRenameErrors tests rename collisions and unknown rename paths.
+func TestWithRenameErrors(t *testing.T) {
+	type CollidingStatus struct {
+		StatusOK    string
+		StatusFound string
+	}
+	_, err := TryNew[CollidingStatus](WithRename(map[string]string{"StatusOK": "StatusFound"}))
+	if err == nil || !strings.Contains(err.Error(), "collides with field StatusFound") {
+		t.Errorf("TryNew error = %v; want collision with field StatusFound", err)
+	}
+	_, err = TryNew[CollidingStatus](WithRename(map[string]string{"StatusOK": "Same", "StatusFound": "Same"}))
+	if err == nil || !strings.Contains(err.Error(), "collides with renamed field StatusOK") {
+		t.Errorf("TryNew error = %v; want collision with renamed field StatusOK", err)
+	}
+	_, err = TryNew[CollidingStatus](WithRename(map[string]string{"StatusOk": "OK"}))
+	if err == nil || !strings.Contains(err.Error(), "unknown rename paths: StatusOk") {
+		t.Errorf("TryNew error = %v; want unknown rename path StatusOk", err)
+	}
+	if _, err := Parse[string](New[CollidingStatus](), "Same"); err == nil {
+		t.Errorf("failed initialization registered an external name")
+	}
+}
+
+// TestWithFieldHook tests the trace of hook calls for a nested enum.
+func TestWithFieldHook(t *testing.T) {
+	var trace []string
+	hook := func(path string, kind reflect.Kind, tag string, value any) {
+		trace = append(trace, fmt.Sprintf("%s %s %q %v", path, kind, tag, value))
+	}
+	New[struct {
+		Name string
+		Code struct {
+			StatusOK int       `enum:"200"`
+			Retries  uint8     `enum:"3"`
+			Label    CodeLabel `enum:"404:Not Found"`
+		}
+		Last int
+	}](WithFieldHook(hook), WithOverrides(map[string]any{"Last": 7}))
+
+	want := []string{
+		`Name string "" Name`,
+		`Code.StatusOK int "200" 200`,
+		`Code.Retries uint8 "3" 3`,
+		`Code.Label struct "404:Not Found" {404 Not Found}`,
+		`Last int "" 7`,
+	}
+	if !reflect.DeepEqual(trace, want) {
+		t.Errorf("hook trace =\n%s\nwant\n%s", strings.Join(trace, "\n"), strings.Join(want, "\n"))
+	}
+
+	if _, err := TryNew[struct{ A int }](WithFieldHook(nil)); err != nil {
+		t.Errorf("TryNew(WithFieldHook(nil)) error = %v", err)
+	}
+
+	defer func() {
+		if r := recover(); r == nil || !strings.Contains(fmt.Sprint(r), "field Code.StatusOK: callback panicked: boom") {
+			t.Errorf("New() panic = %v; want hook panic wrapped with the field path", r)
+		}
+	}()
+	New[struct {
+		Code struct {
+			StatusOK int `enum:"200"`
+		}
+	}](WithFieldHook(func(string, reflect.Kind, string, any) { panic("boom") }))
+}
+
+// TestWithEnvExpansion tests that tags expand environment variables only when enabled.
+func TestWithEnvExpansion(t *testing.T) {
+	t.Setenv("ENUM_TEST_PREFIX", "prod")
+	t.Setenv("ENUM_TEST_PORT", "8080")
+	type Topics struct {
+		Orders string `enum:"${ENUM_TEST_PREFIX}.orders"`
+		Port   uint16 `enum:"${ENUM_TEST_PORT}"`
+		Unset  string `enum:"${ENUM_TEST_UNSET}.users"`
+	}
+
+	got, err := TryNew[Topics](WithEnvExpansion())
+	if err != nil {
+		t.Fatalf("TryNew with WithEnvExpansion returned error: %v", err)
+	}
+	if got.Orders != "prod.orders" || got.Port != 8080 || got.Unset != ".users" {
+		t.Errorf("got %+v; want {Orders:prod.orders Port:8080 Unset:.users}", got)
+	}
+
+	// Without the option, tags are taken literally.
+	_, err = TryNew[Topics]()
+	if err == nil || !strings.Contains(err.Error(), "field Port: invalid enum tag") {
+		t.Errorf("TryNew without WithEnvExpansion error = %v; want the literal port tag rejected", err)
+	}
+
+	_, err = TryNew[Topics](WithStrictEnv())
+	if want := "type enum.Topics field Unset: unset environment variables: ENUM_TEST_UNSET"; err == nil || err.Error() != want {
+		t.Errorf("TryNew with WithStrictEnv error = %v; want %q", err, want)
+	}
+
+	// "$$" escapes a dollar, and patterns keep their anchors.
+	escaped, err := TryNew[struct {
+		Price string         `enum:"$$${ENUM_TEST_PORT}"`
+		Line  *regexp.Regexp `enum:"^[a-z]+$"`
+	}](WithStrictEnv())
+	if err != nil {
+		t.Fatalf("TryNew with escapes and patterns returned error: %v", err)
+	}
+	if escaped.Price != "$8080" || escaped.Line.String() != "^[a-z]+$" {
+		t.Errorf("got Price %q, Line %q; want $8080 and the unexpanded pattern", escaped.Price, escaped.Line)
+	}
+}
+
+// TestWithTrimPrefix tests that listed names lose the prefix while the members keep it.
+func TestWithTrimPrefix(t *testing.T) {
+	status := New[struct {
+		Code struct {
+			Status         int `enum:"0"`
+			StatusOK       int `enum:"200"`
+			StatusNotFound int `enum:"404"`
+		}
+	}]()
+
+	opts := []Option{WithNested(), WithTrimPrefix("Status")}
+	if got, want := Keys(status, opts...), []string{"Code.Status", "Code.OK", "Code.NotFound"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("Keys() = %v; want %v", got, want)
+	}
+	if keys, _ := KeysValues[int](status.Code, WithTrimPrefix("Status")); !reflect.DeepEqual(keys, []string{"Status", "OK", "NotFound"}) {
+		t.Errorf("KeysValues() keys = %v", keys)
+	}
+	if entries := Entries(status, WithTrimPrefix("Status")); entries[1].Name != "Code.OK" || entries[1].Value != 200 {
+		t.Errorf("Entries()[1] = %+v; want Code.OK 200", entries[1])
+	}
+	if got, err := Parse[int](status, "Code.StatusOK"); err != nil || got != 200 {
+		t.Errorf("Parse(Code.StatusOK) = %d, %v; want 200", got, err)
+	}
+}
@@ -0,0 +1,201 @@
+package enum
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// Parse returns the value of the leaf member named by name, which may be a dotted path
+// into nested groups such as "Code.StatusOK". Returns an error if enum is not a struct,
+// if no such member exists, or if the member's type is not V; the latter two list the
+// names of the members of type V like MustParse.
+// With WithIgnoreCase, names are matched case-insensitively when there is no exact match.
+func Parse[V any](enum any, name string, opts ...Option) (V, error) {
+	value, err := parse[V](enum, name, opts)
+	if err != nil && !errors.Is(err, errAmbiguous) {
+		if names := validNames[V](enum, opts); names != "" {
+			err = fmt.Errorf("%v; valid names are %s", err, names)
+		}
+	}
+	return value, err
+}
+
+// parse is Parse without the list of valid names in its errors.
+func parse[V any](enum any, name string, opts []Option) (V, error) {
+	var zero V
+	enumVal, ok := structValue(enum)
+	if !ok {
+		return zero, fmt.Errorf("type %T is not a struct", enum)
+	}
+
+	o := buildOptions(opts)
+	m, err := resolveLeaf(enumVal, name, &o)
+	if err != nil {
+		return zero, err
+	}
+	value, ok := m.value.Interface().(V)
+	if !ok {
+		return zero, fmt.Errorf("member %s has type %s, not %s", name, m.field.Type, reflect.TypeOf((*V)(nil)).Elem())
+	}
+	return value, nil
+}
+
+// Get returns the value of the leaf member named by name, which may be a dotted path.
+// The second result is false if enum is not a struct, if no such member exists, or if
+// the member's type is not V. It accepts the same options as Parse.
+func Get[V any](enum any, name string, opts ...Option) (V, bool) {
+	value, err := parse[V](enum, name, opts)
+	return value, err == nil
+}
+
+// ParseSlice resolves each of names like Parse and returns the values in input order,
+// such as for a list of members read from a configuration file. With WithDedup, values
+// already returned are dropped, so aliases and repeated names yield one value. Returns
+// an error identifying every name that fails to resolve by its index, followed once by
+// the valid names, or if enum is not a struct. An empty input yields an empty, non-nil
+// slice.
+func ParseSlice[V any](enum any, names []string, opts ...Option) ([]V, error) {
+	if _, ok := structValue(enum); !ok {
+		return nil, fmt.Errorf("type %T is not a struct", enum)
+	}
+
+	o := buildOptions(opts)
+	values := make([]V, 0, len(names))
+	seen := make(map[any]bool)
+	var failures []string
+	for i, name := range names {
+		value, err := parse[V](enum, name, opts)
+		if err != nil {
+			failures = append(failures, fmt.Sprintf("[%d] %v", i, err))
+			continue
+		}
+		if o.Dedup {
+			if seen[value] {
+				continue
+			}
+			seen[value] = true
+		}
+		values = append(values, value)
+	}
+	if len(failures) > 0 {
+		if valid := validNames[V](enum, opts); valid != "" {
+			return nil, fmt.Errorf("invalid names: %s; valid names are %s", strings.Join(failures, "; "), valid)
+		}
+		return nil, fmt.Errorf("invalid names: %s", strings.Join(failures, "; "))
+	}
+	return values, nil
+}
+
+// maxSuggestions is the number of valid names listed before truncating if WithMaxListed
+// is not given.
+const maxSuggestions = 10
+
+// MustParse is like Parse but panics if name does not resolve, for package-level lookups
+// where a miss is a programming error. The panic message is the error of Parse, which
+// quotes name and lists the valid names.
+func MustParse[V any](enum any, name string, opts ...Option) V {
+	value, err := Parse[V](enum, name, opts...)
+	if err != nil {
+		panic(err.Error())
+	}
+	return value
+}
+
+// validNames returns the names of the leaf members of enum of type V joined like
+// AllowedNames and capped by WithMaxListed, or at maxSuggestions by default. Returns ""
+// if there are none.
+func validNames[V any](enum any, opts []Option) string {
+	enumVal, ok := structValue(enum)
+	if !ok {
+		return ""
+	}
+	o := buildOptions(opts)
+	var names []string
+	for _, m := range leaves(enumVal, o.tagKey()) {
+		if _, ok := m.value.Interface().(V); ok {
+			names = append(names, m.path)
+		}
+	}
+	limit := o.MaxListed
+	if limit == 0 {
+		limit = maxSuggestions
+	}
+	return joinAllowed(names, ", ", limit)
+}
+
+// GetByTag returns the value of the first leaf member, in declaration order, whose
+// struct tag tagKey equals tagValue and whose type is V. This resolves members by a
+// secondary key such as `alias:"ok"` without maintaining extra maps.
+func GetByTag[V any](enum any, tagKey, tagValue string) (V, bool) {
+	var zero V
+	enumVal, ok := structValue(enum)
+	if !ok {
+		return zero, false
+	}
+
+	for _, m := range leaves(enumVal, "enum") {
+		if tag, ok := m.field.Tag.Lookup(tagKey); ok && tag == tagValue {
+			if value, ok := m.value.Interface().(V); ok {
+				return value, true
+			}
+		}
+	}
+	return zero, false
+}
+
+// NameByTag returns the dotted name of the first leaf member, in declaration order,
+// whose raw tag under the configured tag key is exactly raw, before any parsing, so that
+// "404" matches `enum:"404"` but not `enum:"0404"`. With WithTagFallback, untagged
+// members match the fmt.Sprint form of their value instead, such as "StatusOK" or "3".
+// When several members match, the first one wins. Returns false if enum is not a struct
+// or no member matches.
+func NameByTag(enum any, raw string, opts ...Option) (string, bool) {
+	enumVal, ok := structValue(enum)
+	if !ok {
+		return "", false
+	}
+
+	o := buildOptions(opts)
+	for _, m := range leaves(enumVal, o.tagKey()) {
+		tag := m.field.Tag.Get(o.tagKey())
+		if tag == "" && o.TagFallback {
+			tag = fmt.Sprint(m.value.Interface())
+		}
+		if tag != "" && tag == raw {
+			return m.path, true
+		}
+	}
+	return "", false
+}
+
+// GetAny returns the value of the first of names that names a leaf member of type V,
+// along with that name, such as when supporting both the old and new name of a renamed
+// member during a migration. Returns false if none of the names matches.
+func GetAny[V any](enum any, names ...string) (V, string, bool) {
+	for _, name := range names {
+		if value, ok := Get[V](enum, name); ok {
+			return value, name, true
+		}
+	}
+	var zero V
+	return zero, "", false
+}
+
+// KindOf returns the reflect.Kind of the leaf member named by name, which may be a
+// dotted path, so that generic code can pick the type argument of Get or Parse at
+// runtime. Code-label members report reflect.Struct. It accepts the same options as
+// Parse and returns false if enum is not a struct or has no such member.
+func KindOf(enum any, name string, opts ...Option) (reflect.Kind, bool) {
+	enumVal, ok := structValue(enum)
+	if !ok {
+		return reflect.Invalid, false
+	}
+	o := buildOptions(opts)
+	m, err := resolveLeaf(enumVal, name, &o)
+	if err != nil {
+		return reflect.Invalid, false
+	}
+	return m.field.Type.Kind(), true
+}
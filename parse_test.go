@@ -0,0 +1,290 @@
+package enum
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// TestParse tests Parse with flat and dotted names, unknown names, and type mismatches.
+func TestParse(t *testing.T) {
+	HttpStatus := New[struct {
+		Code struct {
+			StatusOK       int `enum:"200"`
+			StatusNotFound int `enum:"404"`
+		}
+		Default string
+	}]()
+
+	if got, err := Parse[int](HttpStatus, "Code.StatusNotFound"); err != nil || got != 404 {
+		t.Errorf("Parse[int](%q) = %v, %v; want 404, nil", "Code.StatusNotFound", got, err)
+	}
+	if got, err := Parse[string](HttpStatus, "Default"); err != nil || got != "Default" {
+		t.Errorf("Parse[string](%q) = %q, %v; want %q, nil", "Default", got, err, "Default")
+	}
+	if _, err := Parse[int](HttpStatus, "StatusOK"); err == nil || !strings.Contains(err.Error(), "unknown member") {
+		t.Errorf("Parse[int](%q) error = %v; want unknown member error", "StatusOK", err)
+	}
+	if _, err := Parse[string](HttpStatus, "Code.StatusOK"); err == nil || !strings.Contains(err.Error(), "not string") {
+		t.Errorf("Parse[string](%q) error = %v; want type mismatch error", "Code.StatusOK", err)
+	}
+	if _, err := Parse[int](123, "StatusOK"); err == nil {
+		t.Errorf("Parse[int](123) returned nil error; want error")
+	}
+}
+
+// TestGet tests Get with found, missing, and mistyped members.
+func TestGet(t *testing.T) {
+	HttpStatus := New[struct {
+		Code struct {
+			StatusOK int `enum:"200"`
+		}
+	}]()
+	if got, ok := Get[int](HttpStatus, "Code.StatusOK"); !ok || got != 200 {
+		t.Errorf("Get[int](%q) = %v, %v; want 200, true", "Code.StatusOK", got, ok)
+	}
+	if _, ok := Get[string](HttpStatus, "Code.StatusOK"); ok {
+		t.Errorf("Get[string](%q) found a value; want false", "Code.StatusOK")
+	}
+	if _, ok := Get[int](HttpStatus, "Code.Missing"); ok {
+		t.Errorf("Get[int](%q) found a value; want false", "Code.Missing")
+	}
+}
+
+// TestGetByTag tests resolving members by a secondary tag key.
+func TestGetByTag(t *testing.T) {
+	HttpStatus := New[struct {
+		Code struct {
+			StatusOK       int `enum:"200" alias:"ok"`
+			StatusNotFound int `enum:"404" alias:"missing"`
+		}
+		Label string `alias:"ok"`
+	}]()
+
+	if got, ok := GetByTag[int](HttpStatus, "alias", "missing"); !ok || got != 404 {
+		t.Errorf("GetByTag[int](alias, missing) = %v, %v; want 404, true", got, ok)
+	}
+	if got, ok := GetByTag[int](HttpStatus, "alias", "ok"); !ok || got != 200 {
+		t.Errorf("GetByTag[int](alias, ok) = %v, %v; want 200, true", got, ok)
+	}
+	if got, ok := GetByTag[string](HttpStatus, "alias", "ok"); !ok || got != "Label" {
+		t.Errorf("GetByTag[string](alias, ok) = %q, %v; want %q, true", got, ok, "Label")
+	}
+	if got, ok := GetByTag[int](HttpStatus, "enum", "404"); !ok || got != 404 {
+		t.Errorf("GetByTag[int](enum, 404) = %v, %v; want 404, true", got, ok)
+	}
+	if _, ok := GetByTag[int](HttpStatus, "alias", "unknown"); ok {
+		t.Errorf("GetByTag[int](alias, unknown) found a value; want false")
+	}
+}
+
+// TestNameByTag tests raw tag matches, the untagged fallback, and duplicate tags.
+func TestNameByTag(t *testing.T) {
+	status := New[struct {
+		Code struct {
+			StatusOK       int `enum:"200"`
+			StatusNotFound int `enum:"0404"`
+			StatusGone     int `enum:"200"`
+		}
+		Retries uint8
+		Default string
+	}]()
+
+	tests := []struct {
+		raw    string
+		opts   []Option
+		want   string
+		wantOK bool
+	}{
+		{"200", nil, "Code.StatusOK", true},
+		{"0404", nil, "Code.StatusNotFound", true},
+		{"404", nil, "", false},
+		{"Default", nil, "", false},
+		{"Default", []Option{WithTagFallback()}, "Default", true},
+		{"1", []Option{WithTagFallback()}, "Retries", true},
+		{"", []Option{WithTagFallback()}, "", false},
+	}
+	for _, tt := range tests {
+		if got, ok := NameByTag(status, tt.raw, tt.opts...); got != tt.want || ok != tt.wantOK {
+			t.Errorf("NameByTag(%q) = %q, %v; want %q, %v", tt.raw, got, ok, tt.want, tt.wantOK)
+		}
+	}
+	if _, ok := NameByTag(42, "200"); ok {
+		t.Errorf("NameByTag(42) ok = true; want false")
+	}
+}
+
+// TestParseIgnoreCase tests case-insensitive parsing, precedence, ambiguity, and Unicode folding.
+func TestParseIgnoreCase(t *testing.T) {
+	Status := New[struct {
+		Active int `enum:"1"`
+		ACTIVE int `enum:"2"`
+		Closed int `enum:"3"`
+		CLOSED int `enum:"4"`
+		Äpfel  int `enum:"5"`
+		Group  struct {
+			Pending int `enum:"6"`
+		}
+	}]()
+
+	if _, err := Parse[int](Status, "closed"); err == nil {
+		t.Errorf("Parse[int](%q) without WithIgnoreCase returned nil error", "closed")
+	}
+	tests := []struct {
+		name string
+		want int
+	}{
+		{"Active", 1},
+		{"ACTIVE", 2},
+		{"äPFEL", 5},
+		{"group.PENDING", 6},
+	}
+	for _, tt := range tests {
+		if got, err := Parse[int](Status, tt.name, WithIgnoreCase()); err != nil || got != tt.want {
+			t.Errorf("Parse[int](%q, WithIgnoreCase()) = %d, %v; want %d, nil", tt.name, got, err, tt.want)
+		}
+	}
+	_, err := Parse[int](Status, "closed", WithIgnoreCase())
+	if err == nil || !strings.Contains(err.Error(), "ambiguous") || !strings.Contains(err.Error(), "Closed, CLOSED") {
+		t.Errorf("Parse[int](%q, WithIgnoreCase()) error = %v; want ambiguity error", "closed", err)
+	}
+
+	h := Wrap(Status, WithIgnoreCase())
+	if v, err := h.Parse("äpfel"); err != nil || v != 5 {
+		t.Errorf("handle Parse(%q) = %v, %v; want 5, nil", "äpfel", v, err)
+	}
+	if h.Contains("closed") || !h.Contains("GROUP.pending") {
+		t.Errorf("handle Contains returned wrong results with WithIgnoreCase")
+	}
+	if got, err := ParseFlags[int](Status, "Active|äpfel", WithIgnoreCase()); err != nil || got != 1|5 {
+		t.Errorf("ParseFlags(%q, WithIgnoreCase()) = %d, %v; want %d, nil", "Active|äpfel", got, err, 1|5)
+	}
+	if _, err := ParseFlags[int](Status, "active", WithIgnoreCase()); err == nil || !strings.Contains(err.Error(), "ambiguous") {
+		t.Errorf("ParseFlags(%q, WithIgnoreCase()) error = %v; want ambiguity error", "active", err)
+	}
+}
+
+// TestKindOf tests member kinds, including nested members and unknown names.
+func TestKindOf(t *testing.T) {
+	Status := New[struct {
+		Name string
+		Code struct {
+			StatusOK int   `enum:"200"`
+			Retries  uint8 `enum:"3"`
+		}
+		Label CodeLabel `enum:"404:Not Found"`
+	}]()
+	tests := []struct {
+		name string
+		want reflect.Kind
+		ok   bool
+	}{
+		{"Name", reflect.String, true},
+		{"Code.StatusOK", reflect.Int, true},
+		{"Code.Retries", reflect.Uint8, true},
+		{"Label", reflect.Struct, true},
+		{"Code", reflect.Invalid, false},
+		{"Missing", reflect.Invalid, false},
+	}
+	for _, tt := range tests {
+		if got, ok := KindOf(Status, tt.name); got != tt.want || ok != tt.ok {
+			t.Errorf("KindOf(%q) = %v, %v; want %v, %v", tt.name, got, ok, tt.want, tt.ok)
+		}
+	}
+	if _, ok := KindOf(123, "Name"); ok {
+		t.Error("KindOf(123) returned true")
+	}
+}
+
+// TestGetAny tests that the first existing name wins.
+func TestGetAny(t *testing.T) {
+	Status := New[struct {
+		StatusOK int `enum:"200"`
+		Code     struct {
+			Legacy int `enum:"1"`
+		}
+		Name string
+	}]()
+	tests := []struct {
+		names     []string
+		want      int
+		wantName  string
+		wantFound bool
+	}{
+		{[]string{"Success", "StatusOK"}, 200, "StatusOK", true},
+		{[]string{"Code.Legacy", "StatusOK"}, 1, "Code.Legacy", true},
+		{[]string{"Name", "StatusOK"}, 200, "StatusOK", true},
+		{[]string{"Missing", "Name"}, 0, "", false},
+		{nil, 0, "", false},
+	}
+	for _, tt := range tests {
+		got, name, ok := GetAny[int](Status, tt.names...)
+		if got != tt.want || name != tt.wantName || ok != tt.wantFound {
+			t.Errorf("GetAny(%q) = %d, %q, %v; want %d, %q, %v", tt.names, got, name, ok, tt.want, tt.wantName, tt.wantFound)
+		}
+	}
+}
+
+// TestMustParse tests values, variants, and the panic message with truncated suggestions.
+func TestMustParse(t *testing.T) {
+	ports := New[struct {
+		HTTP  int `enum:"80"`
+		HTTPS int `enum:"443"`
+		Admin struct {
+			SSH int `enum:"22"`
+		}
+	}]()
+	if got := MustParse[int](ports, "HTTPS"); got != 443 {
+		t.Errorf("MustParse(HTTPS) = %d; want 443", got)
+	}
+	if got := MustParse[int](ports, "admin.ssh", WithIgnoreCase()); got != 22 {
+		t.Errorf("MustParse(admin.ssh) = %d; want 22", got)
+	}
+
+	panicMessage := func(fn func()) (msg string) {
+		defer func() { msg = fmt.Sprint(recover()) }()
+		fn()
+		return ""
+	}
+	msg := panicMessage(func() { MustParse[int](ports, "HTTPZ") })
+	if !strings.Contains(msg, `"HTTPZ"`) || !strings.Contains(msg, "valid names are HTTP, HTTPS, Admin.SSH") {
+		t.Errorf("MustParse(HTTPZ) panic = %q; want the name and the valid names", msg)
+	}
+
+	msg = panicMessage(func() { MustParse[any](New[BenchStatus](), "Z") })
+	if !strings.HasSuffix(msg, "valid names are A, B, C, D, E, F, G, H, I, J, ...") {
+		t.Errorf("MustParse(Z) panic = %q; want 10 names and an ellipsis", msg)
+	}
+}
+
+// TestParseSlice tests order, deduplication, empty input, and collected failures.
+func TestParseSlice(t *testing.T) {
+	status := New[struct {
+		StatusOK       int `enum:"200"`
+		StatusNotFound int `enum:"404"`
+		Success        int `enum:"200"`
+		Default        string
+	}]()
+
+	got, err := ParseSlice[int](status, []string{"StatusNotFound", "StatusOK", "Success", "StatusNotFound"})
+	if err != nil || !reflect.DeepEqual(got, []int{404, 200, 200, 404}) {
+		t.Errorf("ParseSlice() = %v, %v; want [404 200 200 404]", got, err)
+	}
+	got, err = ParseSlice[int](status, []string{"StatusNotFound", "StatusOK", "Success", "StatusNotFound"}, WithDedup())
+	if err != nil || !reflect.DeepEqual(got, []int{404, 200}) {
+		t.Errorf("ParseSlice(WithDedup) = %v, %v; want [404 200]", got, err)
+	}
+	if got, err := ParseSlice[int](status, nil); err != nil || got == nil || len(got) != 0 {
+		t.Errorf("ParseSlice(nil) = %#v, %v; want an empty non-nil slice", got, err)
+	}
+
+	_, err = ParseSlice[int](status, []string{"StatusOK", "StatusTeapot", "Default"})
+	want := `invalid names: [1] unknown member "StatusTeapot"; [2] member Default has type string, not int; valid names are StatusOK, StatusNotFound, Success`
+	if err == nil || err.Error() != want {
+		t.Errorf("ParseSlice() error = %v; want %s", err, want)
+	}
+	if _, err := ParseSlice[int](42, []string{"A"}); err == nil {
+		t.Errorf("ParseSlice(42) error = nil; want an error")
+	}
+}
@@ -0,0 +1,17 @@
+package enum
+
+import (
+	"reflect"
+	"regexp"
+)
+
+// patternType is the reflect.Type of pattern members: *regexp.Regexp fields holding a
+// regular expression compiled from their tag during initialization, so that a bad
+// pattern fails New instead of its first use. They are the only pointer members
+// allowed, and must be tagged.
+var patternType = reflect.TypeOf((*regexp.Regexp)(nil))
+
+// isPattern reports whether t is the type of pattern members.
+func isPattern(t reflect.Type) bool {
+	return t == patternType
+}
@@ -0,0 +1,50 @@
+package enum
+
+import (
+	"regexp"
+	"strings"
+	"testing"
+)
+
+// TestPattern tests that pattern members are compiled from their tags.
+func TestPattern(t *testing.T) {
+	routes := New[struct {
+		User    *regexp.Regexp `enum:"^/users/([0-9]+)$"`
+		Repeats *regexp.Regexp `enum:"^a{1,3}$"`
+		Name    string
+	}]()
+	if m := routes.User.FindStringSubmatch("/users/42"); len(m) != 2 || m[1] != "42" {
+		t.Errorf("User.FindStringSubmatch(/users/42) = %q; want the id 42", m)
+	}
+	if routes.User.MatchString("/users/me") {
+		t.Error("User matched /users/me")
+	}
+	if !routes.Repeats.MatchString("aaa") || routes.Repeats.MatchString("aaaa") {
+		t.Errorf("Repeats = %v; want the comma kept in the pattern", routes.Repeats)
+	}
+	if routes.Name != "Name" {
+		t.Errorf("got Name %q; want Name", routes.Name)
+	}
+}
+
+// TestPatternInvalid tests that invalid and untagged patterns are rejected.
+func TestPatternInvalid(t *testing.T) {
+	_, err := TryNew[struct {
+		Routes struct {
+			Bad *regexp.Regexp `enum:"^/users/([0-9]+$"`
+		}
+	}]()
+	if err == nil || !strings.HasPrefix(err.Error(), "field Routes.Bad: invalid pattern: error parsing regexp") {
+		t.Errorf("TryNew() error = %v; want an invalid pattern error for Routes.Bad", err)
+	}
+
+	_, err = TryNew[struct{ Untagged *regexp.Regexp }]()
+	if want := "field Untagged: pattern members must be tagged"; err == nil || err.Error() != want {
+		t.Errorf("TryNew() error = %v; want %q", err, want)
+	}
+
+	_, err = TryNew[struct{ Other *string }]()
+	if want := "field Other: pointer types are not supported"; err == nil || err.Error() != want {
+		t.Errorf("TryNew() error = %v; want %q", err, want)
+	}
+}
@@ -0,0 +1,96 @@
+package enum
+
+import (
+	"fmt"
+	"math/rand"
+	"reflect"
+	"sort"
+	"strconv"
+	"sync"
+)
+
+// distributions caches the cumulative weight distributions computed by WeightedRandom,
+// keyed by distributionKey.
+var distributions sync.Map
+
+// distributionKey identifies the members of type value in an enum of type enum, with
+// weights read from the tag under tagKey.
+type distributionKey struct {
+	enum, value reflect.Type
+	tagKey      string
+}
+
+// distribution is the cumulative distribution of the weights of an enum's members.
+type distribution struct {
+	names []string
+	cdf   []float64
+	err   error
+}
+
+// WeightedRandom picks a leaf member of enum of type V at random, with probability
+// proportional to its "weight=" tag option, e.g. `enum:"200,weight=90"`. Members without
+// a weight weigh 1. It returns the member's dotted name and value. The distribution is
+// computed once per enum type and tag key and cached. Returns an error if enum is not a
+// struct, has no members of type V, any weight is negative or not finite, or all weights
+// are zero.
+func WeightedRandom[V any](enum any, rng *rand.Rand, opts ...Option) (string, V, error) {
+	var zero V
+	enumVal, ok := structValue(enum)
+	if !ok {
+		return "", zero, fmt.Errorf("type %T is not a struct", enum)
+	}
+
+	o := buildOptions(opts)
+	key := distributionKey{enumVal.Type(), reflect.TypeOf((*V)(nil)).Elem(), o.tagKey()}
+	cached, ok := distributions.Load(key)
+	if !ok {
+		cached, _ = distributions.LoadOrStore(key, newDistribution(enumVal, key))
+	}
+	d := cached.(*distribution)
+	if d.err != nil {
+		return "", zero, d.err
+	}
+
+	// Find the first member whose cumulative weight exceeds the draw.
+	total := d.cdf[len(d.cdf)-1]
+	draw := rng.Float64() * total
+	i := sort.Search(len(d.cdf), func(i int) bool { return d.cdf[i] > draw })
+	if i == len(d.cdf) {
+		// Rounding can make the draw reach the total; it belongs to the last member.
+		i--
+	}
+	m, _ := findLeaf(enumVal, d.names[i], o.tagKey())
+	return d.names[i], m.value.Interface().(V), nil
+}
+
+// newDistribution computes the cumulative weights of the leaf members of type key.value,
+// read from the tags under key.tagKey.
+func newDistribution(val reflect.Value, key distributionKey) *distribution {
+	d := &distribution{}
+	typ := key.value
+	total := 0.0
+	for _, m := range leaves(val, key.tagKey) {
+		if m.field.Type != typ {
+			continue
+		}
+		weight := 1.0
+		// Parsing rejects weights that are malformed, negative, or not finite.
+		_, options, err := parseTag(m.field.Tag.Get(key.tagKey))
+		if err != nil {
+			return &distribution{err: fmt.Errorf("field %s: invalid enum tag: %v", m.path, err)}
+		}
+		if value, ok := tagOptionValue(options, "weight"); ok {
+			weight, _ = strconv.ParseFloat(value, 64)
+		}
+		total += weight
+		d.names = append(d.names, m.path)
+		d.cdf = append(d.cdf, total)
+	}
+	if len(d.names) == 0 {
+		return &distribution{err: fmt.Errorf("type %s has no members of type %s", val.Type(), typ)}
+	}
+	if total == 0 {
+		return &distribution{err: fmt.Errorf("type %s: all weights of members of type %s are zero", val.Type(), typ)}
+	}
+	return d
+}
@@ -0,0 +1,109 @@
+package enum
+
+import (
+	"math"
+	"math/rand"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// TestWeightedRandom tests that draws follow the configured weights.
+func TestWeightedRandom(t *testing.T) {
+	Status := New[struct {
+		StatusOK       int `enum:"200,weight=90"`
+		StatusNotFound int `enum:"404,weight=8"`
+		StatusTeapot   int `enum:"418,weight=0"`
+		StatusError    int `enum:"500"`
+		Display        string
+	}]()
+
+	rng := rand.New(rand.NewSource(1))
+	const draws = 100000
+	counts := make(map[string]int)
+	for i := 0; i < draws; i++ {
+		name, value, err := WeightedRandom[int](Status, rng)
+		if err != nil {
+			t.Fatalf("WeightedRandom() error = %v", err)
+		}
+		if want, _ := Get[int](Status, name); value != want {
+			t.Fatalf("WeightedRandom() = %s, %d; want value %d", name, value, want)
+		}
+		counts[name]++
+	}
+
+	// The untagged StatusError weighs 1, for a total weight of 99.
+	weights := map[string]float64{"StatusOK": 90, "StatusNotFound": 8, "StatusTeapot": 0, "StatusError": 1}
+	for name, weight := range weights {
+		p := weight / 99
+		if got := float64(counts[name]) / draws; math.Abs(got-p) > 0.005 {
+			t.Errorf("share of %s = %.4f; want %.4f ± 0.005", name, got, p)
+		}
+	}
+}
+
+// TestWeightedRandomErrors tests the rejection of invalid weights.
+func TestWeightedRandomErrors(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	tests := []struct {
+		name string
+		enum any
+		want string
+	}{
+		{"zero", struct {
+			A int `enum:"1,weight=0"`
+			B int `enum:"2,weight=0"`
+		}{}, "all weights"},
+		{"negative", struct {
+			A int `enum:"1,weight=-1"`
+			B int `enum:"2"`
+		}{}, "negative weight"},
+		{"not a number", struct {
+			A int `enum:"1,weight=NaN"`
+			B int `enum:"2"`
+		}{}, "not finite"},
+		{"infinite", struct {
+			A int `enum:"1,weight=Inf"`
+			B int `enum:"2"`
+		}{}, "not finite"},
+		{"no members", struct{ A string }{}, "no members"},
+		{"not a struct", 123, "not a struct"},
+	}
+	for _, tt := range tests {
+		if _, _, err := WeightedRandom[int](tt.enum, rng); err == nil || !strings.Contains(err.Error(), tt.want) {
+			t.Errorf("%s: WeightedRandom() error = %v; want error containing %q", tt.name, err, tt.want)
+		}
+	}
+
+	if _, err := TryNew[struct {
+		A int `enum:"1,weight=heavy"`
+	}](); err == nil {
+		t.Error("TryNew() with an invalid weight returned nil error")
+	}
+	for _, weight := range []string{"NaN", "Inf", "-Inf", "-1"} {
+		tag := reflect.StructTag(`enum:"1,weight=` + weight + `"`)
+		typ := reflect.StructOf([]reflect.StructField{{Name: "A", Type: reflect.TypeOf(0), Tag: tag}})
+		if err := ValidateType(typ); err == nil {
+			t.Errorf("ValidateType() with weight=%s returned nil error", weight)
+		}
+	}
+}
+
+// TestWeightedRandomTagKey tests that weights are read from the configured tag key.
+func TestWeightedRandomTagKey(t *testing.T) {
+	Status := New[struct {
+		StatusOK    int `code:"200,weight=0"`
+		StatusError int `code:"500,weight=1"`
+	}](WithTagKey("code"))
+
+	rng := rand.New(rand.NewSource(1))
+	for i := 0; i < 100; i++ {
+		name, _, err := WeightedRandom[int](Status, rng, WithTagKey("code"))
+		if err != nil {
+			t.Fatalf("WeightedRandom() error = %v", err)
+		}
+		if name != "StatusError" {
+			t.Fatalf("WeightedRandom() = %s; want StatusError, the only member with weight", name)
+		}
+	}
+}
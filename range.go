@@ -0,0 +1,56 @@
+package enum
+
+import (
+	"reflect"
+	"strconv"
+
+	"github.com/tnnmigga/enum/internal/tagrule"
+)
+
+// Range is a member type covering the inclusive span of integers from Lo to Hi, filled
+// from a "lo-hi" tag such as `enum:"200-299"`. Either bound may be negative, as in
+// "-10--1". Range members must be tagged, and Lo must not exceed Hi.
+type Range struct {
+	Lo, Hi int64
+}
+
+// rangeType is the reflect.Type of Range.
+var rangeType = reflect.TypeOf(Range{})
+
+// Contains reports whether v lies within the range, bounds included.
+func (r Range) Contains(v int64) bool {
+	return r.Lo <= v && v <= r.Hi
+}
+
+// String returns the range in its tag form, e.g. "200-299".
+func (r Range) String() string {
+	return strconv.FormatInt(r.Lo, 10) + "-" + strconv.FormatInt(r.Hi, 10)
+}
+
+// isRange reports whether t is the Range type.
+func isRange(t reflect.Type) bool {
+	return t == rangeType
+}
+
+// parseRange parses a "lo-hi" tag with tagrule.ParseRange.
+func parseRange(tag string) (Range, error) {
+	lo, hi, err := tagrule.ParseRange(tag)
+	if err != nil {
+		return Range{}, err
+	}
+	return Range{Lo: lo, Hi: hi}, nil
+}
+
+// inRange reports whether the integer value val falls within one of the Range members.
+func inRange(members []member, val reflect.Value) bool {
+	v, err := signedValue(val)
+	if err != nil {
+		return false
+	}
+	for _, m := range members {
+		if isRange(m.field.Type) && m.value.Interface().(Range).Contains(v) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,93 @@
+package enum
+
+import (
+	"strings"
+	"testing"
+)
+
+// HTTPClass is the enum of status ranges used by the Range tests.
+type HTTPClass struct {
+	Success     Range `enum:"200-299"`
+	ClientError Range `enum:"400-499"`
+	Below       Range `enum:"-10--1"`
+	Single      Range `enum:"7-7"`
+	Teapot      int   `enum:"418"`
+}
+
+// TestRange tests that Range members are filled from "lo-hi" tags.
+func TestRange(t *testing.T) {
+	got := New[HTTPClass]()
+	if got.Success != (Range{200, 299}) || got.ClientError != (Range{400, 499}) {
+		t.Errorf("got %+v, %+v; want {200 299}, {400 499}", got.Success, got.ClientError)
+	}
+	if got.Below != (Range{-10, -1}) || got.Single != (Range{7, 7}) {
+		t.Errorf("got %+v, %+v; want {-10 -1}, {7 7}", got.Below, got.Single)
+	}
+	if s := got.Success.String(); s != "200-299" {
+		t.Errorf("String() = %q; want 200-299", s)
+	}
+}
+
+// TestRangeContains tests Contains at and around the bounds.
+func TestRangeContains(t *testing.T) {
+	r := Range{200, 299}
+	tests := map[int64]bool{199: false, 200: true, 250: true, 299: true, 300: false}
+	for v, want := range tests {
+		if got := r.Contains(v); got != want {
+			t.Errorf("Range{200, 299}.Contains(%d) = %v; want %v", v, got, want)
+		}
+	}
+	if !(Range{7, 7}).Contains(7) || (Range{7, 7}).Contains(8) {
+		t.Error("single-value range does not contain exactly its value")
+	}
+
+	class := New[HTTPClass]()
+	if Contains(class, 204) {
+		t.Error("Contains(204) = true without WithRanges")
+	}
+	if !Contains(class, 204, WithRanges()) || !Contains(class, int8(-5), WithRanges()) {
+		t.Error("Contains() with WithRanges = false for a value within a range")
+	}
+	if Contains(class, 302, WithRanges()) || !Contains(class, 418, WithRanges()) {
+		t.Error("Contains() with WithRanges disagrees outside the ranges")
+	}
+}
+
+// TestRangeInvalid tests that untagged, malformed, and inverted ranges are rejected.
+func TestRangeInvalid(t *testing.T) {
+	tests := []struct {
+		name string
+		fn   func() error
+		want string
+	}{
+		{"untagged", func() error {
+			_, err := TryNew[struct{ R Range }]()
+			return err
+		}, "field R: range members must be tagged"},
+		{"inverted", func() error {
+			_, err := TryNew[struct {
+				R Range `enum:"299-200"`
+			}]()
+			return err
+		}, "inverted"},
+		{"malformed", func() error {
+			_, err := TryNew[struct {
+				R Range `enum:"200"`
+			}]()
+			return err
+		}, "not of the form lo-hi"},
+		{"out of bounds", func() error {
+			_, err := TryNew[struct {
+				R Range `enum:"0-9223372036854775808"`
+			}]()
+			return err
+		}, "out of range"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.fn(); err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("got error %v; want one containing %q", err, tt.want)
+			}
+		})
+	}
+}
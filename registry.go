@@ -0,0 +1,53 @@
+package enum
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"sync"
+)
+
+// registry maps the names given to Register to enum instances.
+var registry sync.Map
+
+// Register makes enum available under name to other enums, whose members can copy the
+// value of one of its members with a tag such as `enum:"@name:Code.StatusOK"`. This
+// keeps parallel enums, such as those of several services, in sync with a single
+// source. Returns an error if enum is not a struct, name is empty or contains ":", or
+// another enum is already registered under name.
+func Register(name string, enum any) error {
+	if _, ok := structValue(enum); !ok {
+		return fmt.Errorf("type %T is not a struct", enum)
+	}
+	if name == "" || strings.Contains(name, ":") {
+		return fmt.Errorf("invalid registry name %q", name)
+	}
+	if _, loaded := registry.LoadOrStore(name, enum); loaded {
+		return fmt.Errorf("an enum is already registered as %q", name)
+	}
+	return nil
+}
+
+// Registered returns the enum registered under name.
+func Registered(name string) (any, bool) {
+	return registry.Load(name)
+}
+
+// resolveReference copies the value of the member referenced by tag, in the form
+// "@name:Member", into field, with the conversions and checks of SetField.
+func resolveReference(field reflect.Value, tag string) error {
+	name, path, _ := strings.Cut(tag[1:], ":")
+	enum, ok := Registered(name)
+	if !ok {
+		return fmt.Errorf("reference %s: no enum registered as %q", tag, name)
+	}
+	enumVal, _ := structValue(enum)
+	m, ok := findLeaf(enumVal, path, "enum")
+	if !ok {
+		return fmt.Errorf("reference %s: unknown member %q", tag, path)
+	}
+	if err := assign(field, m.value.Interface()); err != nil {
+		return fmt.Errorf("reference %s: %v", tag, err)
+	}
+	return nil
+}
@@ -0,0 +1,85 @@
+package enum
+
+import (
+	"strings"
+	"testing"
+)
+
+// TestRegistryReferences tests copying values from a registered enum.
+func TestRegistryReferences(t *testing.T) {
+	source := New[struct {
+		Code struct {
+			StatusOK       int `enum:"200"`
+			StatusNotFound int `enum:"404"`
+		}
+		Reason string    `enum:"not found"`
+		Label  CodeLabel `enum:"500:Internal Error"`
+	}]()
+	if err := Register("registry-test", source); err != nil {
+		t.Fatalf("Register() error = %v", err)
+	}
+	if got, ok := Registered("registry-test"); !ok || got == nil {
+		t.Errorf("Registered() = %v, %v; want the source enum", got, ok)
+	}
+
+	got, err := TryNew[struct {
+		OK     int       `enum:"@registry-test:Code.StatusOK"`
+		Narrow uint16    `enum:"@registry-test:Code.StatusNotFound"`
+		Reason string    `enum:"@registry-test:Reason"`
+		Label  CodeLabel `enum:"@registry-test:Label"`
+		Mail   string    `enum:"@everyone"`
+		User   string    `enum:"\\@user:name"`
+	}]()
+	if err != nil {
+		t.Fatalf("TryNew() error = %v", err)
+	}
+	if got.OK != 200 || got.Narrow != 404 || got.Reason != "not found" || got.Label != (CodeLabel{500, "Internal Error"}) || got.Mail != "@everyone" || got.User != "@user:name" {
+		t.Errorf("TryNew() = %+v; want values copied from the registered enum", got)
+	}
+}
+
+// TestRegistryErrors tests invalid registrations and unresolvable references.
+func TestRegistryErrors(t *testing.T) {
+	if err := Register("registry-errors", struct{ A int }{1}); err != nil {
+		t.Fatalf("Register() error = %v", err)
+	}
+	if err := Register("registry-errors", struct{ B int }{}); err == nil {
+		t.Error("Register() of a taken name returned nil error")
+	}
+	if err := Register("a:b", struct{}{}); err == nil {
+		t.Error("Register() of a name with a colon returned nil error")
+	}
+	if err := Register("registry-int", 1); err == nil {
+		t.Error("Register() of a non-struct returned nil error")
+	}
+
+	tests := []struct {
+		name string
+		new  func() error
+		want string
+	}{
+		{"unknown enum", func() error {
+			_, err := TryNew[struct {
+				A int `enum:"@missing:A"`
+			}]()
+			return err
+		}, `no enum registered as "missing"`},
+		{"unknown member", func() error {
+			_, err := TryNew[struct {
+				A int `enum:"@registry-errors:B"`
+			}]()
+			return err
+		}, `unknown member "B"`},
+		{"type mismatch", func() error {
+			_, err := TryNew[struct {
+				A string `enum:"@registry-errors:A"`
+			}]()
+			return err
+		}, "cannot assign int to string"},
+	}
+	for _, tt := range tests {
+		if err := tt.new(); err == nil || !strings.Contains(err.Error(), tt.want) {
+			t.Errorf("%s: error = %v; want error containing %q", tt.name, err, tt.want)
+		}
+	}
+}
@@ -0,0 +1,43 @@
+package enum
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// JSONSchema returns a JSON Schema restricting a value to the leaf member values of
+// enum, such as {"enum":["StatusOK","StatusNotFound"],"type":"string"} for string
+// members or {"enum":[200,404],"type":"integer"} for integer members, for API validation
+// tooling. Values are listed once each, in declaration order. Returns an error if enum
+// is not a struct, has no members, or its members are not all strings or all integers.
+func JSONSchema(enum any) ([]byte, error) {
+	enumVal, ok := structValue(enum)
+	if !ok {
+		return nil, fmt.Errorf("type %T is not a struct", enum)
+	}
+	members := leaves(enumVal, "enum")
+	if len(members) == 0 {
+		return nil, fmt.Errorf("type %T has no members", enum)
+	}
+
+	schema := struct {
+		Enum []any  `json:"enum"`
+		Type string `json:"type"`
+	}{Type: "string"}
+	class := kindClass(members[0].value.Kind())
+	if class == 'i' {
+		schema.Type = "integer"
+	}
+	seen := make(map[any]bool)
+	for _, m := range members {
+		if c := kindClass(m.value.Kind()); c == 0 || c != class {
+			return nil, fmt.Errorf("cannot build a JSON Schema: %s is %s but %s is %s",
+				members[0].path, members[0].value.Kind(), m.path, m.value.Kind())
+		}
+		if value := m.value.Interface(); !seen[value] {
+			seen[value] = true
+			schema.Enum = append(schema.Enum, value)
+		}
+	}
+	return json.Marshal(schema)
+}
@@ -0,0 +1,44 @@
+package enum
+
+import "testing"
+
+// TestJSONSchema tests schemas of string, integer, and mixed-kind enums.
+func TestJSONSchema(t *testing.T) {
+	tests := []struct {
+		enum any
+		want string
+	}{
+		{New[struct {
+			StatusOK       string
+			StatusNotFound string
+			Alias          string `enum:"StatusOK"`
+		}](), `{"enum":["StatusOK","StatusNotFound"],"type":"string"}`},
+		{New[struct {
+			Code struct {
+				StatusOK       int `enum:"200"`
+				StatusNotFound int `enum:"404"`
+			}
+			Retries uint8 `enum:"3"`
+		}](), `{"enum":[200,404,3],"type":"integer"}`},
+	}
+	for _, tt := range tests {
+		got, err := JSONSchema(tt.enum)
+		if err != nil || string(got) != tt.want {
+			t.Errorf("JSONSchema() = %s, %v; want %s", got, err, tt.want)
+		}
+	}
+
+	_, err := JSONSchema(New[struct {
+		Code int `enum:"200"`
+		Name string
+	}]())
+	if want := "cannot build a JSON Schema: Code is int but Name is string"; err == nil || err.Error() != want {
+		t.Errorf("JSONSchema() of mixed kinds error = %v; want %q", err, want)
+	}
+	if _, err := JSONSchema(struct{}{}); err == nil {
+		t.Error("JSONSchema() of an empty enum returned nil error")
+	}
+	if _, err := JSONSchema(42); err == nil {
+		t.Error("JSONSchema(42) returned nil error")
+	}
+}
@@ -0,0 +1,139 @@
+package enum
+
+// NameSet returns the dotted names of all leaf members of enum as a set, for constant-time
+// membership checks in hot validation loops. Returns nil if enum is not a struct.
+func NameSet(enum any) map[string]struct{} {
+	enumVal, ok := structValue(enum)
+	if !ok {
+		return nil
+	}
+
+	members := leaves(enumVal, "enum")
+	set := make(map[string]struct{}, len(members))
+	for _, m := range members {
+		set[m.path] = struct{}{}
+	}
+	return set
+}
+
+// ValueSet returns the values of all leaf members of enum whose type is V as a set,
+// for constant-time membership checks. Returns nil if enum is not a struct.
+func ValueSet[V comparable](enum any) map[V]struct{} {
+	enumVal, ok := structValue(enum)
+	if !ok {
+		return nil
+	}
+
+	set := make(map[V]struct{})
+	for _, m := range leaves(enumVal, "enum") {
+		if value, ok := m.value.Interface().(V); ok {
+			set[value] = struct{}{}
+		}
+	}
+	return set
+}
+
+// Exhaustive compares the values a switch or handler map covers with the leaf members
+// of enum whose type is V, for tests guarding against unhandled new members. missing
+// lists, in declaration order, the names of the members whose value is not in handled,
+// and extra the values of handled, in order and without repeats, that no member holds.
+// If enum is not a struct, every handled value is extra.
+func Exhaustive[V comparable](enum any, handled []V) (missing []string, extra []V) {
+	covered := SetOf(handled...)
+	values := make(map[V]struct{})
+	if enumVal, ok := structValue(enum); ok {
+		for _, m := range leaves(enumVal, "enum") {
+			value, ok := m.value.Interface().(V)
+			if !ok {
+				continue
+			}
+			values[value] = struct{}{}
+			if !covered.Has(value) {
+				missing = append(missing, m.path)
+			}
+		}
+	}
+	for _, v := range covered.values {
+		if _, ok := values[v]; !ok {
+			extra = append(extra, v)
+		}
+	}
+	return missing, extra
+}
+
+// Set is an immutable set of values with constant-time membership checks, built from
+// the member values of an enum with NewSet or from raw values with SetOf. Its order is
+// deterministic: the order in which values were first added. A Set is safe for
+// concurrent use; the zero value is an empty set.
+type Set[V comparable] struct {
+	values  []V
+	members map[V]struct{}
+}
+
+// NewSet returns the set of the values of all leaf members of enum whose type is V,
+// ordered by declaration. The set is empty if enum is not a struct.
+func NewSet[V comparable](enum any) Set[V] {
+	var values []V
+	if enumVal, ok := structValue(enum); ok {
+		for _, m := range leaves(enumVal, "enum") {
+			if value, ok := m.value.Interface().(V); ok {
+				values = append(values, value)
+			}
+		}
+	}
+	return SetOf(values...)
+}
+
+// SetOf returns the set of values, e.g. to combine a raw slice with an enum's Set.
+func SetOf[V comparable](values ...V) Set[V] {
+	s := Set[V]{members: make(map[V]struct{}, len(values))}
+	for _, v := range values {
+		if _, ok := s.members[v]; !ok {
+			s.members[v] = struct{}{}
+			s.values = append(s.values, v)
+		}
+	}
+	return s
+}
+
+// Has reports whether v is in the set.
+func (s Set[V]) Has(v V) bool {
+	_, ok := s.members[v]
+	return ok
+}
+
+// Len returns the number of values in the set.
+func (s Set[V]) Len() int {
+	return len(s.values)
+}
+
+// Slice returns the values of the set in order.
+func (s Set[V]) Slice() []V {
+	return append([]V(nil), s.values...)
+}
+
+// Union returns the values in s or other: those of s, then those only in other.
+func (s Set[V]) Union(other Set[V]) Set[V] {
+	return SetOf(append(s.Slice(), other.values...)...)
+}
+
+// Intersect returns the values of s that are also in other, in the order of s.
+func (s Set[V]) Intersect(other Set[V]) Set[V] {
+	return s.filter(func(v V) bool { return other.Has(v) })
+}
+
+// Diff returns the values of s that are not in other, in the order of s.
+func (s Set[V]) Diff(other Set[V]) Set[V] {
+	return s.filter(func(v V) bool { return !other.Has(v) })
+}
+
+// filter returns the values of s for which keep returns true.
+func (s Set[V]) filter(keep func(V) bool) Set[V] {
+	var values []V
+	for _, v := range s.values {
+		if keep(v) {
+			values = append(values, v)
+		}
+	}
+	return SetOf(values...)
+}
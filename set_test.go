@@ -0,0 +1,124 @@
+package enum
+
+import (
+	"reflect"
+	"testing"
+)
+
+// TestNameSet tests that NameSet contains the dotted names of all leaf members.
+func TestNameSet(t *testing.T) {
+	HttpStatus := New[struct {
+		Code struct {
+			StatusOK       int `enum:"200"`
+			StatusNotFound int `enum:"404"`
+		}
+		Default string
+	}]()
+
+	got := NameSet(HttpStatus)
+	want := map[string]struct{}{"Code.StatusOK": {}, "Code.StatusNotFound": {}, "Default": {}}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("NameSet(%v) = %v; want %v", HttpStatus, got, want)
+	}
+	if got := NameSet(123); got != nil {
+		t.Errorf("NameSet(123) = %v; want nil", got)
+	}
+}
+
+// TestValueSet tests that ValueSet contains only the values of the requested type.
+func TestValueSet(t *testing.T) {
+	HttpStatus := New[struct {
+		Code struct {
+			StatusOK       int `enum:"200"`
+			StatusNotFound int `enum:"404"`
+		}
+		Default string
+	}]()
+
+	got := ValueSet[int](HttpStatus)
+	want := map[int]struct{}{200: {}, 404: {}}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("ValueSet[int](%v) = %v; want %v", HttpStatus, got, want)
+	}
+	if _, ok := ValueSet[string](HttpStatus)["Default"]; !ok {
+		t.Errorf("ValueSet[string](%v) is missing %q", HttpStatus, "Default")
+	}
+}
+
+// TestSet tests membership, ordering, and the set algebra.
+func TestSet(t *testing.T) {
+	Status := New[struct {
+		StatusOK       int `enum:"200"`
+		StatusCreated  int `enum:"201"`
+		Alias          int `enum:"200"`
+		StatusNotFound int `enum:"404"`
+		Name           string
+	}]()
+	s := NewSet[int](Status)
+	if s.Len() != 3 || !s.Has(404) || s.Has(500) {
+		t.Errorf("NewSet() = %v; want {200, 201, 404}", s.Slice())
+	}
+	if got, want := s.Slice(), []int{200, 201, 404}; !reflect.DeepEqual(got, want) {
+		t.Errorf("Slice() = %v; want %v", got, want)
+	}
+	s.Slice()[0] = 0
+	if !s.Has(200) || s.Slice()[0] != 200 {
+		t.Error("modifying Slice() changed the set")
+	}
+
+	other := SetOf(404, 500, 201)
+	tests := []struct {
+		name string
+		got  Set[int]
+		want []int
+	}{
+		{"Union", s.Union(other), []int{200, 201, 404, 500}},
+		{"Intersect", s.Intersect(other), []int{201, 404}},
+		{"Diff", s.Diff(other), []int{200}},
+		{"Diff reversed", other.Diff(s), []int{500}},
+		{"Union empty", s.Union(Set[int]{}), []int{200, 201, 404}},
+		{"Intersect empty", s.Intersect(Set[int]{}), nil},
+	}
+	for _, tt := range tests {
+		if got := tt.got.Slice(); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("%s = %v; want %v", tt.name, got, tt.want)
+		}
+	}
+	if (Set[int]{}).Has(0) || NewSet[int](123).Len() != 0 {
+		t.Error("empty sets report members")
+	}
+
+	if allocs := testing.AllocsPerRun(100, func() { s.Has(404) }); allocs != 0 {
+		t.Errorf("Has() allocates %v times; want 0", allocs)
+	}
+}
+
+// TestExhaustive tests missing members and extra handled values.
+func TestExhaustive(t *testing.T) {
+	HttpStatus := New[struct {
+		Code struct {
+			StatusOK       int `enum:"200"`
+			StatusNotFound int `enum:"404"`
+			StatusTeapot   int `enum:"418"`
+		}
+		Default string
+	}]()
+
+	handlers := map[int]string{200: "ok", 404: "not found"}
+	var handled []int
+	for code := range handlers {
+		handled = append(handled, code)
+	}
+	missing, extra := Exhaustive(HttpStatus, handled)
+	if !reflect.DeepEqual(missing, []string{"Code.StatusTeapot"}) || extra != nil {
+		t.Errorf("Exhaustive() = %v, %v; want [Code.StatusTeapot], nil", missing, extra)
+	}
+
+	missing, extra = Exhaustive(HttpStatus, []int{200, 404, 418, 500, 500})
+	if missing != nil || !reflect.DeepEqual(extra, []int{500}) {
+		t.Errorf("Exhaustive() = %v, %v; want nil, [500]", missing, extra)
+	}
+	if missing, extra := Exhaustive(123, []int{1}); missing != nil || !reflect.DeepEqual(extra, []int{1}) {
+		t.Errorf("Exhaustive(123) = %v, %v; want nil, [1]", missing, extra)
+	}
+}
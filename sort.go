@@ -0,0 +1,39 @@
+package enum
+
+import "sort"
+
+// SortByDeclaration sorts values in place by the declaration order of the leaf members
+// of enum with type V that hold them, rather than by the values themselves. Values that
+// are not member values sort to the end. The sort is stable, so duplicates and
+// non-member values keep their relative order.
+func SortByDeclaration[V comparable](enum any, values []V) {
+	compare := CompareByDeclaration[V](enum)
+	sort.SliceStable(values, func(i, j int) bool { return compare(values[i], values[j]) < 0 })
+}
+
+// CompareByDeclaration returns a comparison function, usable with slices.SortFunc, that
+// orders values by the declaration order of the leaf members of enum with type V that
+// hold them. Values that are not member values order after all member values and equal
+// to each other. Member positions are computed once, when CompareByDeclaration is called.
+func CompareByDeclaration[V comparable](enum any) func(a, b V) int {
+	positions := make(map[V]int)
+	if enumVal, ok := structValue(enum); ok {
+		for _, m := range leaves(enumVal, "enum") {
+			if value, ok := m.value.Interface().(V); ok {
+				if _, seen := positions[value]; !seen {
+					positions[value] = len(positions)
+				}
+			}
+		}
+	}
+
+	position := func(v V) int {
+		if i, ok := positions[v]; ok {
+			return i
+		}
+		return len(positions)
+	}
+	return func(a, b V) int {
+		return position(a) - position(b)
+	}
+}
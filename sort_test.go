@@ -0,0 +1,42 @@
+package enum
+
+import (
+	"reflect"
+	"testing"
+)
+
+// TestSortByDeclaration tests declaration-order sorting with duplicates and non-members.
+func TestSortByDeclaration(t *testing.T) {
+	Status := New[struct {
+		StatusNotFound int `enum:"404"`
+		StatusOK       int `enum:"200"`
+		Group          struct {
+			StatusError int `enum:"500"`
+		}
+		Name string
+	}]()
+
+	values := []int{200, 999, 500, 404, 200, 1, 404}
+	SortByDeclaration(Status, values)
+	if want := []int{404, 404, 200, 200, 500, 999, 1}; !reflect.DeepEqual(values, want) {
+		t.Errorf("SortByDeclaration() = %v; want %v", values, want)
+	}
+
+	compare := CompareByDeclaration[int](Status)
+	tests := []struct {
+		a, b int
+		sign int
+	}{
+		{404, 200, -1},
+		{500, 200, 1},
+		{200, 200, 0},
+		{500, 999, -1},
+		{999, 1, 0},
+	}
+	for _, tt := range tests {
+		got := compare(tt.a, tt.b)
+		if (got < 0 && tt.sign >= 0) || (got > 0 && tt.sign <= 0) || (got == 0 && tt.sign != 0) {
+			t.Errorf("compare(%d, %d) = %d; want sign %d", tt.a, tt.b, got, tt.sign)
+		}
+	}
+}
@@ -0,0 +1,31 @@
+package enum
+
+import (
+	"reflect"
+	"strings"
+)
+
+// SQLCheck returns a SQL CHECK constraint expression restricting column to the string
+// leaf values of enum, such as "status IN ('StatusOK','StatusNotFound')", in declaration
+// order and without repetitions. Single quotes in values are doubled; column is used
+// as is. Returns "" if enum is not a struct or has no string members.
+func SQLCheck(enum any, column string) string {
+	enumVal, ok := structValue(enum)
+	if !ok {
+		return ""
+	}
+
+	var values []string
+	seen := make(map[string]bool)
+	for _, m := range leaves(enumVal, "enum") {
+		if m.value.Kind() != reflect.String || seen[m.value.String()] {
+			continue
+		}
+		seen[m.value.String()] = true
+		values = append(values, "'"+strings.ReplaceAll(m.value.String(), "'", "''")+"'")
+	}
+	if len(values) == 0 {
+		return ""
+	}
+	return column + " IN (" + strings.Join(values, ",") + ")"
+}
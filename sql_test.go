@@ -0,0 +1,29 @@
+package enum
+
+import "testing"
+
+// TestSQLCheck tests CHECK constraints built from string members.
+func TestSQLCheck(t *testing.T) {
+	status := New[struct {
+		Code struct {
+			StatusOK int `enum:"200"`
+		}
+		Type struct {
+			StatusOK       string
+			StatusNotFound string
+			Again          string `enum:"StatusOK"`
+		}
+		Quoted string `enum:"it's"`
+	}]()
+	want := "status IN ('StatusOK','StatusNotFound','it''s')"
+	if got := SQLCheck(status, "status"); got != want {
+		t.Errorf("SQLCheck() = %q; want %q", got, want)
+	}
+
+	if got := SQLCheck(struct{ A int }{}, "a"); got != "" {
+		t.Errorf("SQLCheck() without string members = %q; want empty", got)
+	}
+	if got := SQLCheck(42, "a"); got != "" {
+		t.Errorf("SQLCheck(42) = %q; want empty", got)
+	}
+}
@@ -0,0 +1,109 @@
+package enum
+
+import (
+	"fmt"
+	"go/format"
+	"io"
+	"reflect"
+	"strings"
+)
+
+// GenerateSwitch writes to w a gofmt-formatted Go switch statement over the variable
+// varName with one case per leaf member of enum and a default that panics, for pasting
+// or go:generate, so that linters such as exhaustive can then track it. typeName names
+// the type of varName in the panic message, which uses fmt.
+//
+// Cases name the constants a member would be declared as: its dotted path with the dots
+// removed, such as StatusOK for StatusOK or CodeStatusOK for Code.StatusOK. With
+// WithLiteralCases they hold the member's value instead, followed by its name in a
+// comment. Members sharing a value share a case, since Go rejects duplicate constant
+// cases. Pass a group such as Status.Code to switch over part of an enum. With
+// WithTodoCases the switch is a skeleton to fill in, with a TODO comment on every case
+// and a default that does not panic.
+// Returns an error if enum is not a struct, has no members, its members do not all
+// have the same type, or, without WithLiteralCases, two members share a constant name,
+// such as Code.OK and CodeOK.
+func GenerateSwitch(w io.Writer, enum any, varName, typeName string, opts ...Option) error {
+	o := buildOptions(opts)
+	enumVal, ok := structValue(enum)
+	if !ok {
+		return fmt.Errorf("type %T is not a struct", enum)
+	}
+	members := leaves(enumVal, o.tagKey())
+	if len(members) == 0 {
+		return fmt.Errorf("type %T has no members", enum)
+	}
+
+	// Group members by value, keeping the order of their first declaration.
+	type switchCase struct {
+		value any
+		names []string
+	}
+	var cases []*switchCase
+	byValue := make(map[any]*switchCase)
+	byConst := make(map[string]string)
+	for _, m := range members {
+		if m.field.Type != members[0].field.Type {
+			return fmt.Errorf("members %s and %s have different types %s and %s",
+				members[0].path, m.path, members[0].field.Type, m.field.Type)
+		}
+		if !o.LiteralCases {
+			name := constName(m.path)
+			if other, ok := byConst[name]; ok {
+				return fmt.Errorf("members %s and %s share the constant name %s", other, m.path, name)
+			}
+			byConst[name] = m.path
+		}
+		if c, ok := byValue[m.value.Interface()]; ok {
+			c.names = append(c.names, m.path)
+			continue
+		}
+		c := &switchCase{value: m.value.Interface(), names: []string{m.path}}
+		byValue[c.value] = c
+		cases = append(cases, c)
+	}
+
+	var buf strings.Builder
+	fmt.Fprintf(&buf, "switch %s {\n", varName)
+	for _, c := range cases {
+		if o.LiteralCases {
+			lit, err := (&generator{}).literal(reflect.ValueOf(c.value), c.names[0])
+			if err != nil {
+				return err
+			}
+			fmt.Fprintf(&buf, "case %s: // ", lit)
+			if o.TodoCases {
+				buf.WriteString("TODO: handle ")
+			}
+			fmt.Fprintf(&buf, "%s\n", strings.Join(c.names, ", "))
+			continue
+		}
+		fmt.Fprintf(&buf, "case %s:", constName(c.names[0]))
+		switch {
+		case o.TodoCases:
+			fmt.Fprintf(&buf, " // TODO: handle %s", strings.Join(c.names, ", "))
+		case len(c.names) > 1:
+			fmt.Fprintf(&buf, " // also %s", strings.Join(c.names[1:], ", "))
+		}
+		buf.WriteString("\n")
+	}
+	if o.TodoCases {
+		buf.WriteString("default: // TODO: handle unknown values\n")
+	} else {
+		buf.WriteString("default:\n")
+		fmt.Fprintf(&buf, "panic(fmt.Sprintf(%q, %s))\n", "unexpected "+typeName+": %v", varName)
+	}
+	buf.WriteString("}\n")
+
+	src, err := format.Source([]byte(buf.String()))
+	if err != nil {
+		return fmt.Errorf("formatting generated switch: %v", err)
+	}
+	_, err = w.Write(src)
+	return err
+}
+
+// constName returns the constant name of the member at the dotted path.
+func constName(path string) string {
+	return strings.ReplaceAll(path, ".", "")
+}
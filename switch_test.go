@@ -0,0 +1,157 @@
+package enum
+
+import (
+	"go/ast"
+	"go/importer"
+	"go/parser"
+	"go/token"
+	"go/types"
+	"strings"
+	"testing"
+)
+
+// SwitchStatus is the enum of the GenerateSwitch tests, with an alias of StatusOK.
+type SwitchStatus struct {
+	StatusOK       int `enum:"200"`
+	StatusNotFound int `enum:"404"`
+	Success        int `enum:"200"`
+}
+
+// TestGenerateSwitch tests the switch emitted with constant names and with literals.
+func TestGenerateSwitch(t *testing.T) {
+	status := New[SwitchStatus]()
+
+	var names strings.Builder
+	if err := GenerateSwitch(&names, status, "s", "Status"); err != nil {
+		t.Fatalf("GenerateSwitch() error = %v", err)
+	}
+	want := "switch s {\n" +
+		"case StatusOK: // also Success\n" +
+		"case StatusNotFound:\n" +
+		"default:\n" +
+		"\tpanic(fmt.Sprintf(\"unexpected Status: %v\", s))\n" +
+		"}\n"
+	if names.String() != want {
+		t.Errorf("GenerateSwitch() =\n%s\nwant\n%s", names.String(), want)
+	}
+
+	var literals strings.Builder
+	if err := GenerateSwitch(&literals, status, "code", "int", WithLiteralCases()); err != nil {
+		t.Fatalf("GenerateSwitch(WithLiteralCases) error = %v", err)
+	}
+	want = "switch code {\n" +
+		"case 200: // StatusOK, Success\n" +
+		"case 404: // StatusNotFound\n" +
+		"default:\n" +
+		"\tpanic(fmt.Sprintf(\"unexpected int: %v\", code))\n" +
+		"}\n"
+	if literals.String() != want {
+		t.Errorf("GenerateSwitch(WithLiteralCases) =\n%s\nwant\n%s", literals.String(), want)
+	}
+
+	// Both switches compile against matching declarations.
+	typeCheck(t, "type Status int\n"+
+		"const (\n\tStatusOK Status = 200\n\tStatusNotFound Status = 404\n)\n"+
+		"func f(s Status) {\n"+names.String()+"}\n"+
+		"func g(code int) {\n"+literals.String()+"}\n")
+}
+
+// TestGenerateSwitchNested tests constant names of nested members and mixed types.
+func TestGenerateSwitchNested(t *testing.T) {
+	status := New[struct {
+		Code struct {
+			StatusOK int `enum:"200"`
+		}
+		Type struct {
+			StatusOK string
+		}
+	}]()
+
+	var buf strings.Builder
+	if err := GenerateSwitch(&buf, status.Type, "s", "string", WithLiteralCases()); err != nil {
+		t.Fatalf("GenerateSwitch(Type) error = %v", err)
+	}
+	if !strings.Contains(buf.String(), "case \"StatusOK\": // StatusOK\n") {
+		t.Errorf("GenerateSwitch(Type) =\n%s\nwant a string literal case", buf.String())
+	}
+	buf.Reset()
+	if err := GenerateSwitch(&buf, status.Code, "s", "int"); err != nil || !strings.Contains(buf.String(), "case StatusOK:") {
+		t.Errorf("GenerateSwitch(Code) = %s, %v", buf.String(), err)
+	}
+
+	err := GenerateSwitch(&buf, status, "s", "Status")
+	if want := "members Code.StatusOK and Type.StatusOK have different types int and string"; err == nil || err.Error() != want {
+		t.Errorf("GenerateSwitch() error = %v; want %q", err, want)
+	}
+	if err := GenerateSwitch(&buf, struct{}{}, "s", "Status"); err == nil {
+		t.Error("GenerateSwitch() of an empty enum returned nil error")
+	}
+}
+
+// TestGenerateSwitchTodo tests the skeleton emitted with WithTodoCases.
+func TestGenerateSwitchTodo(t *testing.T) {
+	status := New[struct {
+		Code struct {
+			StatusOK int `enum:"200"`
+			Success  int `enum:"200"`
+		}
+		Default int
+	}]()
+
+	var buf strings.Builder
+	if err := GenerateSwitch(&buf, status, "s", "int", WithTodoCases()); err != nil {
+		t.Fatalf("GenerateSwitch(WithTodoCases) error = %v", err)
+	}
+	want := "switch s {\n" +
+		"case CodeStatusOK: // TODO: handle Code.StatusOK, Code.Success\n" +
+		"case Default: // TODO: handle Default\n" +
+		"default: // TODO: handle unknown values\n" +
+		"}\n"
+	if buf.String() != want {
+		t.Errorf("GenerateSwitch(WithTodoCases) =\n%s\nwant\n%s", buf.String(), want)
+	}
+	typeCheck(t, "var _ = fmt.Sprint\nconst (\n\tCodeStatusOK = 200\n\tDefault = 0\n)\nfunc f(s int) {\n"+buf.String()+"}\n")
+
+	buf.Reset()
+	if err := GenerateSwitch(&buf, status, "s", "int", WithTodoCases(), WithLiteralCases()); err != nil {
+		t.Fatalf("GenerateSwitch(WithTodoCases, WithLiteralCases) error = %v", err)
+	}
+	if !strings.Contains(buf.String(), "case 200: // TODO: handle Code.StatusOK, Code.Success\n") {
+		t.Errorf("GenerateSwitch(WithTodoCases, WithLiteralCases) =\n%s\nwant TODO literal cases", buf.String())
+	}
+}
+
+// TestGenerateSwitchConstCollision tests that members sharing a constant name are
+// rejected unless the cases hold literals.
+func TestGenerateSwitchConstCollision(t *testing.T) {
+	status := New[struct {
+		Code struct {
+			OK int `enum:"200"`
+		}
+		CodeOK int `enum:"201"`
+	}]()
+
+	var buf strings.Builder
+	err := GenerateSwitch(&buf, status, "s", "int")
+	if want := "members Code.OK and CodeOK share the constant name CodeOK"; err == nil || err.Error() != want {
+		t.Errorf("GenerateSwitch() error = %v; want %q", err, want)
+	}
+	if err := GenerateSwitch(&buf, status, "s", "int", WithLiteralCases()); err != nil {
+		t.Errorf("GenerateSwitch(WithLiteralCases) error = %v", err)
+	}
+}
+
+// typeCheck fails the test if body, declarations importing fmt, does not type-check.
+func typeCheck(t *testing.T, body string) {
+	t.Helper()
+	src := "package p\n\nimport \"fmt\"\n\n" + body
+	fset := token.NewFileSet()
+	file, err := parser.ParseFile(fset, "switch.go", src, 0)
+	if err != nil {
+		t.Fatalf("parsing generated code: %v\n%s", err, src)
+	}
+	conf := types.Config{Importer: importer.ForCompiler(fset, "source", nil)}
+	if _, err := conf.Check("p", fset, []*ast.File{file}, nil); err != nil {
+		t.Errorf("type-checking generated code: %v\n%s", err, src)
+	}
+}
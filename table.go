@@ -0,0 +1,143 @@
+package enum
+
+import (
+	"fmt"
+	"io"
+	"reflect"
+	"strings"
+	"text/tabwriter"
+)
+
+// WriteTable writes enum to w as a table aligned with text/tabwriter, with a header row
+// and one row per member, for debugging or a CLI's --list-values output. Nested groups
+// are written as a row holding the group name, followed by their members indented by
+// two spaces; without a ColumnName column, only the members are written. WithColumns
+// selects the columns, and WithOrder the order of the members within each group; groups
+// keep their place. Returns an error if enum is not a struct, if no columns are
+// selected, if the members cannot be ordered as requested, or if writing fails.
+//
+// For example:
+//
+//	NAME              VALUE      TAG                         DESCRIPTION
+//	Code
+//	  StatusOK        200        200,desc=Request succeeded  Request succeeded
+//	  StatusNotFound  404        404,desc=No such resource   No such resource
+//	Default           "Default"
+func WriteTable(w io.Writer, enum any, opts ...Option) error {
+	o := buildOptions(opts)
+	enumVal, ok := structValue(enum)
+	if !ok {
+		return fmt.Errorf("type %T is not a struct", enum)
+	}
+
+	columns := o.Columns
+	if columns == nil {
+		columns = []Column{ColumnName, ColumnValue, ColumnTag}
+		for _, m := range leaves(enumVal, o.tagKey()) {
+			if description(m, &o) != "" {
+				columns = append(columns, ColumnDescription)
+				break
+			}
+		}
+	}
+	if len(columns) == 0 {
+		return fmt.Errorf("no columns selected")
+	}
+
+	// Align the whole table, then trim the padding that empty trailing cells leave.
+	var buf strings.Builder
+	tw := tabwriter.NewWriter(&buf, 0, 0, 2, ' ', 0)
+	header := make([]string, len(columns))
+	for i, c := range columns {
+		header[i] = columnHeaders[c]
+	}
+	fmt.Fprintln(tw, strings.Join(header, "\t"))
+	if err := writeTableRows(tw, enumVal, "", "", columns, &o); err != nil {
+		return err
+	}
+	if err := tw.Flush(); err != nil {
+		return err
+	}
+	lines := strings.SplitAfter(buf.String(), "\n")
+	for i, line := range lines {
+		if line != "" {
+			lines[i] = strings.TrimRight(line, " \n") + "\n"
+		}
+	}
+	_, err := io.WriteString(w, strings.Join(lines, ""))
+	return err
+}
+
+// writeTableRows writes the rows of the struct val at path, with names indented by indent.
+func writeTableRows(w io.Writer, val reflect.Value, path, indent string, columns []Column, o *Options) error {
+	// Sort the members of this group among themselves, leaving groups in place.
+	typ := val.Type()
+	var fields, members []member
+	for i := 0; i < val.NumField(); i++ {
+		field := typ.Field(i)
+		if !val.Field(i).CanInterface() || isSentinel(field, o.tagKey()) {
+			continue
+		}
+		m := member{path: o.join(path, field.Name), field: field, value: val.Field(i)}
+		fields = append(fields, m)
+		if !isGroup(field.Type) {
+			members = append(members, m)
+		}
+	}
+	if err := sortMembers(members, o.Order); err != nil {
+		return err
+	}
+
+	next := 0
+	for _, f := range fields {
+		if isGroup(f.field.Type) {
+			if i := columnIndex(columns, ColumnName); i >= 0 {
+				cells := make([]string, len(columns))
+				cells[i] = indent + f.field.Name
+				fmt.Fprintln(w, strings.Join(cells, "\t"))
+			}
+			if err := writeTableRows(w, f.value, f.path, indent+"  ", columns, o); err != nil {
+				return err
+			}
+			continue
+		}
+
+		m := members[next]
+		next++
+		cells := make([]string, len(columns))
+		for i, c := range columns {
+			switch c {
+			case ColumnName:
+				cells[i] = indent + m.field.Name
+			case ColumnValue:
+				cells[i] = formatValue(m.value.Interface())
+			case ColumnTag:
+				cells[i] = m.field.Tag.Get(o.tagKey())
+			case ColumnDescription:
+				cells[i] = description(m, o)
+			}
+		}
+		fmt.Fprintln(w, strings.Join(cells, "\t"))
+	}
+	return nil
+}
+
+// columnIndex returns the position of c in columns, or -1 if it is absent.
+func columnIndex(columns []Column, c Column) int {
+	for i, column := range columns {
+		if column == c {
+			return i
+		}
+	}
+	return -1
+}
+
+// description returns the "desc=" tag option of the member m, falling back to
+// Options.Descriptions.
+func description(m member, o *Options) string {
+	_, options, _ := parseTag(m.field.Tag.Get(o.tagKey()))
+	if desc, ok := tagOptionValue(options, "desc"); ok {
+		return desc
+	}
+	return o.Descriptions[m.path]
+}
@@ -0,0 +1,90 @@
+package enum
+
+import (
+	"flag"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// update rewrites the golden files of the table tests.
+var update = flag.Bool("update", false, "update golden files")
+
+// TableStatus is a nested enum with descriptions and non-ASCII values.
+type TableStatus struct {
+	Code struct {
+		StatusOK       int `enum:"200,desc=Request succeeded"`
+		StatusNotFound int `enum:"404,desc=No such resource"`
+	}
+	Greeting string `enum:"grüße,desc=Umlauts keep the columns aligned"`
+	Default  string
+}
+
+// PlainStatus is a nested enum without descriptions.
+type PlainStatus struct {
+	Type struct {
+		StatusOK string
+		Teapot   string `enum:"I'm a teapot"`
+	}
+	Retries uint8 `enum:"3"`
+}
+
+// TestWriteTable tests the rendered tables against golden files.
+func TestWriteTable(t *testing.T) {
+	tests := []struct {
+		golden string
+		enum   any
+		opts   []Option
+	}{
+		{"table_descriptions.golden", New[TableStatus](), nil},
+		{"table_plain.golden", New[PlainStatus](), nil},
+		{"table_columns.golden", New[TableStatus](), []Option{WithColumns(ColumnName, ColumnDescription), WithOrder(ByName)}},
+		{"table_by_value.golden", New[TableStatus](), []Option{WithColumns(ColumnName, ColumnValue), WithOrder(ByValueDesc)}},
+		{"table_value_first.golden", New[TableStatus](), []Option{WithColumns(ColumnValue, ColumnName)}},
+		{"table_no_name.golden", New[TableStatus](), []Option{WithColumns(ColumnValue, ColumnTag)}},
+		{"table_external.golden", New[PlainStatus](), []Option{WithDescriptions(map[string]string{"Type.Teapot": "Short and stout", "Retries": "Attempts before giving up"})}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.golden, func(t *testing.T) {
+			var buf strings.Builder
+			if err := WriteTable(&buf, tt.enum, tt.opts...); err != nil {
+				t.Fatalf("WriteTable() error = %v", err)
+			}
+			path := filepath.Join("testdata", tt.golden)
+			if *update {
+				if err := os.WriteFile(path, []byte(buf.String()), 0o644); err != nil {
+					t.Fatal(err)
+				}
+			}
+			want, err := os.ReadFile(path)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if buf.String() != string(want) {
+				t.Errorf("WriteTable() =\n%s\nwant\n%s", buf.String(), want)
+			}
+		})
+	}
+}
+
+// TestWriteTableErrors tests invalid enums, orders, and failing writers.
+func TestWriteTableErrors(t *testing.T) {
+	var buf strings.Builder
+	if err := WriteTable(&buf, 42); err == nil {
+		t.Error("WriteTable(42) returned nil error")
+	}
+	mixed := New[struct {
+		A int
+		B string
+	}]()
+	if err := WriteTable(&buf, mixed, WithOrder(ByValue)); err == nil {
+		t.Error("WriteTable() of mixed kinds by value returned nil error")
+	}
+	if err := WriteTable(&buf, New[PlainStatus](), WithColumns()); err == nil {
+		t.Error("WriteTable() without columns returned nil error")
+	}
+	if err := WriteTable(&failingWriter{}, New[PlainStatus]()); err != errWriteFailed {
+		t.Errorf("WriteTable(failing) error = %v; want the write error", err)
+	}
+}
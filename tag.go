@@ -0,0 +1,164 @@
+package enum
+
+import (
+	"reflect"
+	"strings"
+
+	"github.com/tnnmigga/enum/internal/tagrule"
+)
+
+// The grammar of leaf tags and the integer range checks live in internal/tagrule, so
+// that the static checker in package enumcheck applies the same rules.
+type tagOption = tagrule.Option
+
+var (
+	parseTag    = tagrule.Parse
+	splitTag    = tagrule.Split
+	unescapeTag = tagrule.Unescape
+)
+
+// tagOptionValue returns the value of the last option named key.
+func tagOptionValue(options []tagOption, key string) (string, bool) {
+	for i := len(options) - 1; i >= 0; i-- {
+		if options[i].Key == key {
+			return options[i].Value, true
+		}
+	}
+	return "", false
+}
+
+// isMarker reports whether field is a blank marker field carrying a tag under key.
+func isMarker(field reflect.StructField, key string) bool {
+	_, ok := field.Tag.Lookup(key)
+	return field.Name == "_" && ok
+}
+
+// groupOptions holds the options of a nested group's tag, such as "default=unknown".
+type groupOptions struct {
+	// defaultTag replaces the tag of untagged members if hasDefault is set. Otherwise,
+	// untagged members copy the member of the sibling group same, if set.
+	defaultTag string
+	hasDefault bool
+	same       string
+
+	// prefixes are the string prefixes of the enclosing groups, outermost first.
+	prefixes []groupPrefix
+
+	// offsetSum is the sum of the integer offsets of the enclosing groups, and
+	// taggedOffsetSum the sum of those that also apply to tagged members.
+	offsetSum, taggedOffsetSum int64
+
+	// base replaces the start index of the group's untagged integer members if hasBase
+	// is set. It is not inherited through inherit but through the initializer's options.
+	base    int64
+	hasBase bool
+}
+
+// groupPrefix is a group's "prefix=" option; tagged reports whether it also applies to
+// explicitly tagged string members.
+type groupPrefix struct {
+	prefix string
+	tagged bool
+}
+
+// parseGroupTag parses the options of a nested group's tag with tagrule.ParseGroup,
+// which the enumcheck analyzer applies too. A tag without options, such as "group1",
+// is ignored, as group tags used to be.
+func parseGroupTag(tag string) (groupOptions, error) {
+	parsed, err := tagrule.ParseGroup(tag)
+	if err != nil {
+		return groupOptions{}, err
+	}
+	group := groupOptions{
+		defaultTag: parsed.Default,
+		hasDefault: parsed.HasDefault,
+		same:       parsed.Same,
+		offsetSum:  parsed.Offset,
+		base:       parsed.Base,
+		hasBase:    parsed.HasBase,
+	}
+	if parsed.HasPrefix {
+		group.prefixes = []groupPrefix{{prefix: parsed.Prefix, tagged: parsed.Tagged}}
+	}
+	if !parsed.Absolute {
+		group.taggedOffsetSum = group.offsetSum
+	}
+	return group, nil
+}
+
+// inherit returns the options of a group nested in a group with options g: the
+// default tag or mirrored group set by the inner group takes precedence, and prefixes
+// compose from the
+// outer group to the inner one. Returns an error if the summed offsets overflow int64.
+func (g groupOptions) inherit(inner groupOptions) (groupOptions, error) {
+	if inner.hasDefault || inner.same != "" {
+		g.defaultTag, g.hasDefault, g.same = inner.defaultTag, inner.hasDefault, inner.same
+	}
+	if len(inner.prefixes) > 0 {
+		prefixes := make([]groupPrefix, 0, len(g.prefixes)+len(inner.prefixes))
+		g.prefixes = append(append(prefixes, g.prefixes...), inner.prefixes...)
+	}
+	var err error
+	if g.offsetSum, err = addOffset(g.offsetSum, inner.offsetSum); err != nil {
+		return g, err
+	}
+	if g.taggedOffsetSum, err = addOffset(g.taggedOffsetSum, inner.taggedOffsetSum); err != nil {
+		return g, err
+	}
+	return g, nil
+}
+
+// offset returns the summed offsets of the enclosing groups for an integer member,
+// skipping those marked absolute if tagged is set.
+func (g groupOptions) offset(tagged bool) int64 {
+	if tagged {
+		return g.taggedOffsetSum
+	}
+	return g.offsetSum
+}
+
+// prefix returns the composed prefixes of the enclosing groups for a string member,
+// skipping those that do not apply to tagged members if tagged is set.
+func (g groupOptions) prefix(tagged bool) string {
+	var buf strings.Builder
+	for _, p := range g.prefixes {
+		if !tagged || p.tagged {
+			buf.WriteString(p.prefix)
+		}
+	}
+	return buf.String()
+}
+
+// namingStyles maps the names accepted by the "case" marker option to naming styles.
+var namingStyles = map[string]NamingStyle{
+	"asis":            NamingAsIs,
+	"snake":           NamingSnake,
+	"screaming_snake": NamingScreamingSnake,
+	"kebab":           NamingKebab,
+	"camel":           NamingCamel,
+	"lower":           NamingLower,
+	"upper":           NamingUpper,
+}
+
+// applyMarker applies the options of a marker field tag such as
+// "start=100,step=10,case=snake,strict" to o. Returns an error for unknown keys and
+// invalid values.
+func applyMarker(o *Options, tag string) error {
+	marker, err := tagrule.ParseMarker(tag)
+	if err != nil {
+		return err
+	}
+	if marker.HasStart {
+		o.StartIndex = marker.Start
+	}
+	if marker.HasStep {
+		o.IndexStep = marker.Step
+	}
+	if marker.HasCase {
+		o.NamingStyle = namingStyles[marker.Case]
+	}
+	if marker.HasStrict {
+		o.Strict = marker.Strict
+	}
+	return nil
+}
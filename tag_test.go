@@ -0,0 +1,31 @@
+package enum
+
+import (
+	"reflect"
+	"testing"
+)
+
+// TestParseTag tests splitting tags into values and options.
+func TestParseTag(t *testing.T) {
+	tests := []struct {
+		tag     string
+		value   string
+		options []tagOption
+	}{
+		{"404", "404", nil},
+		{"Hello, world", "Hello, world", nil},
+		{"a=b,c=d", "a=b,c=d", nil},
+		{`404,i18n.en=Not Found,i18n.de=Nicht gefunden`, "404", []tagOption{{Key: "i18n.en", Value: "Not Found"}, {Key: "i18n.de", Value: "Nicht gefunden"}}},
+		{`a\,b,i18n.en=x\, y`, "a,b", []tagOption{{Key: "i18n.en", Value: "x, y"}}},
+		{",i18n.en=Default", "", []tagOption{{Key: "i18n.en", Value: "Default"}}},
+	}
+	for _, tt := range tests {
+		value, options, err := parseTag(tt.tag)
+		if err != nil || value != tt.value || !reflect.DeepEqual(options, tt.options) {
+			t.Errorf("parseTag(%q) = %q, %v, %v; want %q, %v, nil", tt.tag, value, options, err, tt.value, tt.options)
+		}
+	}
+	if _, _, err := parseTag("1,i18n.=x"); err == nil {
+		t.Errorf("parseTag(%q) returned nil error", "1,i18n.=x")
+	}
+}
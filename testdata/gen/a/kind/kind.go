@@ -0,0 +1,4 @@
+package kind
+
+// Kind is an integer kind used by the GenerateSource tests.
+type Kind int
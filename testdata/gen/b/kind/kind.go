@@ -0,0 +1,4 @@
+package kind
+
+// Kind is a string kind used by the GenerateSource tests.
+type Kind string
@@ -0,0 +1,82 @@
+package enum
+
+import "reflect"
+
+// Node is a node in the hierarchy of an enum returned by Tree. Leaf nodes carry the
+// member's Value and have no children; group nodes carry their members as Children,
+// in declaration order, and a nil Value.
+type Node struct {
+	Name     string
+	Value    any
+	Children []*Node
+}
+
+// Tree returns the nested structure of enum as a tree rooted at a node named after the
+// enum's type (empty for anonymous struct types). Unlike the dotted-path helpers it
+// preserves grouping, which suits tree-rendered interfaces. Unexported fields are skipped,
+// as are sentinels, with count sentinels read under the key given by WithTagKey.
+// Returns nil if enum is not a struct.
+func Tree(enum any, opts ...Option) *Node {
+	enumVal, ok := structValue(enum)
+	if !ok {
+		return nil
+	}
+	o := buildOptions(opts)
+	return buildTree(enumVal.Type().Name(), enumVal, o.tagKey())
+}
+
+// buildTree returns the group node for the struct value val, skipping the sentinels
+// under tagKey.
+func buildTree(name string, val reflect.Value, tagKey string) *Node {
+	node := &Node{Name: name}
+	typ := val.Type()
+	for i := 0; i < val.NumField(); i++ {
+		fieldVal := val.Field(i)
+		if !fieldVal.CanInterface() {
+			continue
+		}
+
+		fieldType := typ.Field(i)
+		if isSentinel(fieldType, tagKey) {
+			continue
+		}
+		if isGroup(fieldType.Type) {
+			node.Children = append(node.Children, buildTree(fieldType.Name, fieldVal, tagKey))
+			continue
+		}
+		node.Children = append(node.Children, &Node{Name: fieldType.Name, Value: fieldVal.Interface()})
+	}
+	return node
+}
+
+// Shape returns the number of nested groups and of leaf members in the struct type T,
+// counting the same fields Tree would, from the type alone without constructing an
+// enum. It helps size slices and maps keyed by members ahead of iterating. It accepts
+// WithTagKey like Tree. Returns zeros if T is not a struct.
+func Shape[T any](opts ...Option) (groups, leaves int) {
+	typ := reflect.TypeOf((*T)(nil)).Elem()
+	if typ.Kind() != reflect.Struct {
+		return 0, 0
+	}
+	o := buildOptions(opts)
+	return shapeOf(typ, o.tagKey())
+}
+
+// shapeOf counts the nested groups and leaf members of the struct type typ, skipping
+// the sentinels under tagKey.
+func shapeOf(typ reflect.Type, tagKey string) (groups, leaves int) {
+	for i := 0; i < typ.NumField(); i++ {
+		field := typ.Field(i)
+		if !field.IsExported() || isSentinel(field, tagKey) {
+			continue
+		}
+		if isGroup(field.Type) {
+			g, l := shapeOf(field.Type, tagKey)
+			groups += g + 1
+			leaves += l
+			continue
+		}
+		leaves++
+	}
+	return groups, leaves
+}
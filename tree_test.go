@@ -0,0 +1,59 @@
+package enum
+
+import (
+	"reflect"
+	"testing"
+)
+
+// TestTree tests the tree of a named nested enum.
+func TestTree(t *testing.T) {
+	type HttpStatus struct {
+		Code struct {
+			StatusOK       int `enum:"200"`
+			StatusNotFound int `enum:"404"`
+		}
+		Default string
+	}
+
+	got := Tree(New[HttpStatus]())
+	want := &Node{Name: "HttpStatus", Children: []*Node{
+		{Name: "Code", Children: []*Node{
+			{Name: "StatusOK", Value: 200},
+			{Name: "StatusNotFound", Value: 404},
+		}},
+		{Name: "Default", Value: "Default"},
+	}}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Tree() = %+v; want %+v", got, want)
+	}
+	if got := Tree(123); got != nil {
+		t.Errorf("Tree(123) = %+v; want nil", got)
+	}
+}
+
+// TestShape tests counting the groups and leaves of enum types.
+func TestShape(t *testing.T) {
+	type Layout struct {
+		_    struct{} `enum:"start=1"`
+		Code struct {
+			StatusOK int
+			Inner    struct {
+				Deep   string
+				Deeper struct{ Deepest string }
+			}
+		}
+		Label  CodeLabel
+		All    []Entry `enum:"entries"`
+		hidden int
+		Name   string
+	}
+	if groups, leaves := Shape[Layout](); groups != 3 || leaves != 5 {
+		t.Errorf("Shape[Layout]() = %d, %d; want 3, 5", groups, leaves)
+	}
+	if groups, leaves := Shape[struct{}](); groups != 0 || leaves != 0 {
+		t.Errorf("Shape[struct{}]() = %d, %d; want 0, 0", groups, leaves)
+	}
+	if groups, leaves := Shape[int](); groups != 0 || leaves != 0 {
+		t.Errorf("Shape[int]() = %d, %d; want 0, 0", groups, leaves)
+	}
+}
@@ -0,0 +1,41 @@
+package enum
+
+import (
+	"fmt"
+	"net/url"
+	"reflect"
+	"strconv"
+)
+
+// ToURLValues returns the leaf members of enum as url.Values, keyed by dotted name, so
+// that an enum of request parameters can serve as a query template. Strings are used as
+// is, integers are formatted in decimal with strconv, and code-label members in their
+// "code:label" tag form. Returns nil if enum is not a struct.
+func ToURLValues(enum any) url.Values {
+	enumVal, ok := structValue(enum)
+	if !ok {
+		return nil
+	}
+
+	values := make(url.Values)
+	for _, m := range leaves(enumVal, "enum") {
+		values.Set(m.path, formatParam(m.value))
+	}
+	return values
+}
+
+// formatParam formats a member value as a request parameter.
+func formatParam(val reflect.Value) string {
+	switch val.Kind() {
+	case reflect.String:
+		return val.String()
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return strconv.FormatInt(val.Int(), 10)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return strconv.FormatUint(val.Uint(), 10)
+	}
+	if isCodeLabel(val.Type()) {
+		return formatParam(val.FieldByName("Code")) + ":" + formatParam(val.FieldByName("Label"))
+	}
+	return fmt.Sprint(val.Interface())
+}
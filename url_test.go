@@ -0,0 +1,37 @@
+package enum
+
+import (
+	"net/url"
+	"reflect"
+	"testing"
+)
+
+// TestToURLValues tests that leaf members become dotted query parameters.
+func TestToURLValues(t *testing.T) {
+	Params := New[struct {
+		Query string `enum:"go enum"`
+		Page  int    `enum:"-1"`
+		Limit uint16 `enum:"50"`
+		Sort  struct {
+			Field string `enum:"name"`
+		}
+		Status CodeLabel `enum:"404:Not Found"`
+	}]()
+	want := url.Values{
+		"Query":      {"go enum"},
+		"Page":       {"-1"},
+		"Limit":      {"50"},
+		"Sort.Field": {"name"},
+		"Status":     {"404:Not Found"},
+	}
+	got := ToURLValues(Params)
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("ToURLValues() = %v; want %v", got, want)
+	}
+	if enc := got.Encode(); enc != "Limit=50&Page=-1&Query=go+enum&Sort.Field=name&Status=404%3ANot+Found" {
+		t.Errorf("ToURLValues().Encode() = %q", enc)
+	}
+	if got := ToURLValues(123); got != nil {
+		t.Errorf("ToURLValues(123) = %v; want nil", got)
+	}
+}
@@ -0,0 +1,218 @@
+package enum
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+// member is an exported field reached while walking an enum struct.
+type member struct {
+	path  string
+	field reflect.StructField
+	value reflect.Value
+}
+
+// isGroup reports whether fields of type t are nested groups of members rather than
+// members themselves: structs are groups unless they have a member shape such as
+// a code-label pair or a Range.
+func isGroup(t reflect.Type) bool {
+	return t.Kind() == reflect.Struct && !isCodeLabel(t) && !isRange(t)
+}
+
+// entriesType is the type of entries sentinel fields, such as All []enum.Entry tagged
+// enum:"entries", which New fills with the entries of their sibling members.
+// Sentinels are not members themselves.
+var entriesType = reflect.TypeOf([]Entry(nil))
+
+// isSentinel reports whether field is an entries sentinel, or a count sentinel under
+// tagKey, rather than a member.
+func isSentinel(field reflect.StructField, tagKey string) bool {
+	return field.Type == entriesType || isCount(field, tagKey)
+}
+
+// isCount reports whether field is a count sentinel: an integer field tagged "count"
+// under tagKey, which New fills with the number of its sibling members.
+func isCount(field reflect.StructField, tagKey string) bool {
+	return isIntegerKind(field.Type.Kind()) && field.Tag.Get(tagKey) == "count"
+}
+
+// structValue returns the reflect.Value of enum if it holds a struct, dereferencing a
+// non-nil pointer to a struct so that the query helpers accept both.
+func structValue(enum any) (reflect.Value, bool) {
+	enumVal := reflect.ValueOf(enum)
+	if enumVal.Kind() == reflect.Ptr && !enumVal.IsNil() {
+		enumVal = enumVal.Elem()
+	}
+	if enumVal.Kind() != reflect.Struct {
+		return reflect.Value{}, false
+	}
+	return enumVal, true
+}
+
+// leaves returns the leaf members of a struct in declaration order, descending into
+// nested structs and skipping the sentinels under tagKey. Leaf paths join the names of
+// enclosing fields with ".". Unexported fields are skipped.
+func leaves(val reflect.Value, tagKey string) []member {
+	var members []member
+	walkLeaves(val, "", tagKey, func(m member) {
+		members = append(members, m)
+	})
+	return members
+}
+
+// walkLeaves calls fn for each leaf member of val, prefixing paths with prefix.
+func walkLeaves(val reflect.Value, prefix, tagKey string, fn func(member)) {
+	typ := val.Type()
+	for i := 0; i < val.NumField(); i++ {
+		fieldVal := val.Field(i)
+		if !fieldVal.CanInterface() {
+			continue
+		}
+
+		fieldType := typ.Field(i)
+		if isSentinel(fieldType, tagKey) {
+			continue
+		}
+		path := fieldType.Name
+		if prefix != "" {
+			path = prefix + "." + path
+		}
+
+		// Descend into nested groups; everything else is a leaf.
+		if isGroup(fieldType.Type) {
+			walkLeaves(fieldVal, path, tagKey, fn)
+			continue
+		}
+		fn(member{path: path, field: fieldType, value: fieldVal})
+	}
+}
+
+// findLeaf returns the leaf member of val named by a dotted path such as "Code.StatusOK",
+// which is not a sentinel under tagKey.
+func findLeaf(val reflect.Value, path, tagKey string) (member, bool) {
+	var m member
+	for _, segment := range strings.Split(path, ".") {
+		if !isGroup(val.Type()) {
+			return member{}, false
+		}
+		field, ok := val.Type().FieldByName(segment)
+		if !ok || len(field.Index) != 1 || field.PkgPath != "" {
+			return member{}, false
+		}
+
+		val = val.Field(field.Index[0])
+		if m.path != "" {
+			m.path += "."
+		}
+		m.path += field.Name
+		m.field = field
+		m.value = val
+	}
+
+	// Groups and sentinels are not leaf members.
+	if m.field.Type == nil || isGroup(m.field.Type) || isSentinel(m.field, tagKey) {
+		return member{}, false
+	}
+	return m, true
+}
+
+// errAmbiguous is wrapped by errors for names that fold to several members.
+var errAmbiguous = errors.New("ambiguous")
+
+// resolveLeaf returns the leaf member of val named by name like findLeaf, also accepting
+// the external names given by o.Renames for any segment of the path. If o.IgnoreCase is
+// set and there is no exact match, it falls back to a case-insensitive comparison of
+// whole dotted paths using Unicode case folding, failing if more than one member matches.
+func resolveLeaf(val reflect.Value, name string, o *Options) (member, error) {
+	if m, ok := findLeaf(val, name, o.tagKey()); ok {
+		return m, nil
+	}
+	if len(o.Renames) > 0 {
+		for _, m := range leaves(val, o.tagKey()) {
+			if o.matchesRenamed(m.path, name) {
+				return m, nil
+			}
+		}
+	}
+	if o.IgnoreCase {
+		var matches []member
+		for _, m := range leaves(val, o.tagKey()) {
+			if strings.EqualFold(m.path, name) {
+				matches = append(matches, m)
+			}
+		}
+		if len(matches) == 1 {
+			return matches[0], nil
+		}
+		if len(matches) > 1 {
+			paths := make([]string, len(matches))
+			for i, m := range matches {
+				paths[i] = m.path
+			}
+			return member{}, fmt.Errorf("member %q is %w: matches %s", name, errAmbiguous, strings.Join(paths, ", "))
+		}
+	}
+	return member{}, fmt.Errorf("unknown member %q", name)
+}
+
+// sortMembers sorts members in place according to order; ties keep declaration order.
+// Ordering by value fails unless the members all hold strings or all hold integers.
+func sortMembers(members []member, order Order) error {
+	switch order {
+	case ByName:
+		sort.SliceStable(members, func(i, j int) bool { return members[i].path < members[j].path })
+	case ByValue, ByValueDesc:
+		for _, m := range members {
+			if kindClass(m.value.Kind()) != kindClass(members[0].value.Kind()) || kindClass(m.value.Kind()) == 0 {
+				return fmt.Errorf("cannot order members by value: %s is %s but %s is %s",
+					members[0].path, members[0].value.Kind(), m.path, m.value.Kind())
+			}
+		}
+		sort.SliceStable(members, func(i, j int) bool {
+			if order == ByValueDesc {
+				return lessValue(members[j].value, members[i].value)
+			}
+			return lessValue(members[i].value, members[j].value)
+		})
+	}
+	return nil
+}
+
+// kindClass groups the kinds that can be ordered against each other: 's' for strings,
+// 'i' for integers, and 0 for unorderable kinds.
+func kindClass(kind reflect.Kind) byte {
+	switch {
+	case kind == reflect.String:
+		return 's'
+	case isIntegerKind(kind):
+		return 'i'
+	}
+	return 0
+}
+
+// lessValue reports whether the string or integer value a orders before b. Signed and
+// unsigned integers compare by their mathematical value.
+func lessValue(a, b reflect.Value) bool {
+	if a.Kind() == reflect.String {
+		return a.String() < b.String()
+	}
+	aNeg := isSignedKind(a.Kind()) && a.Int() < 0
+	bNeg := isSignedKind(b.Kind()) && b.Int() < 0
+	if aNeg != bNeg {
+		return aNeg
+	}
+	if aNeg {
+		return a.Int() < b.Int()
+	}
+	aBits, _ := integerBits(a)
+	bBits, _ := integerBits(b)
+	return aBits < bBits
+}
+
+// isSignedKind reports whether kind is a signed integer kind.
+func isSignedKind(kind reflect.Kind) bool {
+	return kind >= reflect.Int && kind <= reflect.Int64
+}